@@ -7,31 +7,36 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/grafana/pyroscope-go"
 	gomes "github.com/jeffersonbrasilino/gomes"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user"
 	"github.com/jeffersonbrasilino/hex-api-go/pkg"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/trace"
+	pkghttp "github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/log"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/observability"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// shutdownTimeout bounds the flush of telemetry and the in-flight requests.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 
+	logConfig := setupLogging()
 	slog.Info("starting api server...")
-	httpServer := gin.Default()
+	httpServer := gin.New()
+	httpServer.Use(pkghttp.Recovery())
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	dbConn := connectToDatabase()
-	//tp := initOtelTraceProvider()
-	//initPyroscope()
+	dbConn := connectToDatabase(logConfig)
+	telemetry := startTelemetry(ctx, httpServer, dbConn)
+	httpServer.Use(pkghttp.RequestLogger())
 	
 	//bootstrap modules
 	modules := []pkg.Module{
@@ -44,8 +49,17 @@ func main() {
 		}
 	}
 	
-	gomes.Start()
-	//gomes.EnableOtelTrace()
+	if err := gomes.Start(); err != nil {
+		panic(err)
+	}
+
+	for _, module := range modules {
+		if consumerModule, ok := module.(pkg.ConsumerModule); ok {
+			if err := consumerModule.StartConsumers(ctx); err != nil {
+				panic(err)
+			}
+		}
+	}
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", os.Getenv("APP_PORT")),
@@ -60,16 +74,42 @@ func main() {
 	}()
 
 	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	for _, module := range modules {
+		if consumerModule, ok := module.(pkg.ConsumerModule); ok {
+			consumerModule.StopConsumers()
+		}
+	}
+	if err := telemetry.Shutdown(shutdownCtx); err != nil {
+		slog.Error("telemetry shutdown error", "error", err)
+	}
 	gomes.Shutdown()
-	//tp.Shutdown(ctx)
-	if err := server.Shutdown(ctx); err != nil {
+	if err := server.Shutdown(shutdownCtx); err != nil {
 		slog.Info("shutting down server error")
 	}
 	slog.Info("shutdown completed")
 
 }
 
-func connectToDatabase() *gorm.DB {
+// setupLogging sends the application, gin and gorm logs through the pkg/log
+// handler.
+func setupLogging() *log.Config {
+	config, err := log.ConfigFromEnv()
+	if err != nil {
+		panic(fmt.Errorf("invalid log config: %w", err))
+	}
+
+	slog.SetDefault(log.New(os.Stdout, config))
+	gin.DebugPrintFunc = func(format string, values ...any) {
+		slog.Debug(strings.TrimSpace(fmt.Sprintf(format, values...)), "component", "gin")
+	}
+
+	return config
+}
+
+func connectToDatabase(logConfig *log.Config) *gorm.DB {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s",
 		os.Getenv("POSTGRES_HOST"),
 		os.Getenv("POSTGRES_USER"),
@@ -77,7 +117,9 @@ func connectToDatabase() *gorm.DB {
 		os.Getenv("POSTGRES_DBNAME"),
 		os.Getenv("POSTGRES_PORT"))
 
-	dbConn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	dbConn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: log.NewGormLogger(slog.Default(), logConfig),
+	})
 
 	if err != nil {
 		panic(fmt.Errorf("failed to connect to database: %w", err))
@@ -86,54 +128,20 @@ func connectToDatabase() *gorm.DB {
 	return dbConn
 }
 
-func initOtelTraceProvider() *trace.TracerProvider {
-	exporter, err := otlptracegrpc.New(context.Background())
+func startTelemetry(ctx context.Context, httpServer *gin.Engine, dbConn *gorm.DB) *observability.Telemetry {
+	config, err := observability.ConfigFromEnv()
 	if err != nil {
-		panic(fmt.Errorf("failed to create OTLP grpc exporter: %w", err))
+		panic(fmt.Errorf("invalid telemetry config: %w", err))
 	}
 
-	batchSpanProcessor := trace.NewBatchSpanProcessor(exporter)
-	provider := trace.NewTracerProvider(
-		trace.WithSpanProcessor(batchSpanProcessor),
-	)
+	telemetry, err := observability.Start(ctx, config)
+	if err != nil {
+		panic(err)
+	}
 
-	otel.SetTracerProvider(provider)
-	otel.SetTextMapPropagator(propagation.TraceContext{})
-	return provider
-}
+	if err := telemetry.Instrument(httpServer, dbConn); err != nil {
+		panic(err)
+	}
 
-func initPyroscope() {
-	// These 2 lines are only required if you're using mutex or block profiling
-	// Read the explanation below for how to set these rates:
-	//runtime.SetMutexProfileFraction(5)
-	//runtime.SetBlockProfileRate(5)
-
-	pyroscope.Start(pyroscope.Config{
-		ApplicationName: os.Getenv("APP_NAME"),
-
-		// replace this with the address of pyroscope server
-		ServerAddress: os.Getenv("PYROSCOPE_SERVER_ADDRESS"),
-
-		// you can disable logging by setting this to nil
-		Logger: nil,
-
-		// you can provide static tags via a map:
-		Tags: map[string]string{"hostname": os.Getenv("HOSTNAME")},
-
-		ProfileTypes: []pyroscope.ProfileType{
-			// these profile types are enabled by default:
-			pyroscope.ProfileCPU,
-			pyroscope.ProfileAllocObjects,
-			pyroscope.ProfileAllocSpace,
-			pyroscope.ProfileInuseObjects,
-			pyroscope.ProfileInuseSpace,
-
-			// these profile types are optional:
-			pyroscope.ProfileGoroutines,
-			pyroscope.ProfileMutexCount,
-			pyroscope.ProfileMutexDuration,
-			pyroscope.ProfileBlockCount,
-			pyroscope.ProfileBlockDuration,
-		},
-	})
+	return telemetry
 }
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/database"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/migration"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// sources lists the migrations of every module, new modules register here.
+var sources = map[string]migration.Source{
+	"user": database.MigrationSource(),
+}
+
+const usage = `usage: migrate [-module name] <command>
+
+commands:
+  up             apply every pending migration
+  down N         revert the last N applied migrations
+  status         list migrations and when they were applied
+  create <name>  create an empty up/down pair for -module
+`
+
+func main() {
+	module := flag.String("module", "", "restrict the command to one module (required by create)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, *module, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, module string, args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("command is required")
+	}
+
+	selected, err := selectSources(module)
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "create" {
+		if module == "" || len(args) != 2 {
+			return fmt.Errorf("usage: migrate -module <name> create <migration name>")
+		}
+
+		dir := filepath.Join("internal", module, "infrastructure", "database", "migrations")
+		up, down, err := migration.Create(dir, args[1], time.Now())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("created %s\ncreated %s\n", up, down)
+		return nil
+	}
+
+	db, err := connectToDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migration.NewMigrator(migration.NewPostgresStore(db), selected...)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %s\n", m)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+	case "down":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: migrate down N")
+		}
+
+		steps, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid number of steps %q", args[1])
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %s\n", m)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func selectSources(module string) ([]migration.Source, error) {
+	if module != "" {
+		source, ok := sources[module]
+		if !ok {
+			return nil, fmt.Errorf("unknown module %q", module)
+		}
+		return []migration.Source{source}, nil
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	selected := make([]migration.Source, 0, len(names))
+	for _, name := range names {
+		selected = append(selected, sources[name])
+	}
+	return selected, nil
+}
+
+func printStatus(statuses []migration.Status) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "MODULE\tVERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.Changed {
+			appliedAt += " (changed after applied)"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", status.Migration.Module, status.Migration.Version, status.Migration.Name, appliedAt)
+	}
+	writer.Flush()
+}
+
+func connectToDatabase() (*sql.DB, error) {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s",
+		os.Getenv("POSTGRES_HOST"),
+		os.Getenv("POSTGRES_USER"),
+		os.Getenv("POSTGRES_PASS"),
+		os.Getenv("POSTGRES_DBNAME"),
+		os.Getenv("POSTGRES_PORT"))
+
+	dbConn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return dbConn.DB()
+}
@@ -0,0 +1,10 @@
+package addgroupuser
+
+type Command struct {
+	GroupId string `json:"groupId"`
+	UserId  string `json:"userId"`
+}
+
+func (c *Command) Name() string {
+	return "addGroupUser"
+}
@@ -0,0 +1,33 @@
+package auth
+
+type LoginCommand struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	DeviceId string `json:"deviceId"`
+	// Ip and UserAgent describe the client, kept as the device last seen
+	// data
+	Ip        string `json:"ip"`
+	UserAgent string `json:"userAgent"`
+}
+
+func (c *LoginCommand) Name() string {
+	return "login"
+}
+
+type RefreshCommand struct {
+	RefreshToken string `json:"refreshToken"`
+	Ip           string `json:"ip"`
+	UserAgent    string `json:"userAgent"`
+}
+
+func (c *RefreshCommand) Name() string {
+	return "refreshToken"
+}
+
+type LogoutCommand struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (c *LogoutCommand) Name() string {
+	return "logout"
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+)
+
+type LoginHandler struct {
+	repository    contract.UserRepository
+	refreshTokens contract.RefreshTokenRepository
+	hasher        contract.PasswordHasher
+	issuer        *tokenIssuer
+	dummyHash     func() string
+}
+
+func NewLoginHandler(
+	repository contract.UserRepository,
+	refreshTokens contract.RefreshTokenRepository,
+	hasher contract.PasswordHasher,
+	accessTokens contract.AccessTokenIssuer,
+	refreshTokenTTL time.Duration,
+) *LoginHandler {
+	return &LoginHandler{
+		repository:    repository,
+		refreshTokens: refreshTokens,
+		hasher:        hasher,
+		issuer:        &tokenIssuer{accessTokens, refreshTokenTTL},
+		dummyHash: sync.OnceValue(func() string {
+			hash, _ := hasher.Hash(uuid.NewString())
+			return hash
+		}),
+	}
+}
+
+func (h *LoginHandler) Handle(ctx context.Context, data *LoginCommand) (*Response, error) {
+	user, err := h.repository.FindByUsername(ctx, data.Username)
+
+	var notFound *ddgo.NotFoundError
+	if errors.As(err, &notFound) {
+		// keep the response time of unknown usernames close to a wrong password
+		h.hasher.Verify(h.dummyHash(), data.Password)
+		return nil, errInvalidCredentials()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.VerifyPassword(data.Password, h.hasher) {
+		return nil, errInvalidCredentials()
+	}
+
+	// only told after the password matched, so it does not reveal accounts
+	if !user.IsActive() {
+		return nil, apperror.NewForbiddenError("user account is inactive")
+	}
+
+	deviceId := data.DeviceId
+	if deviceId == "" {
+		deviceId = uuid.NewString()
+	}
+
+	now := time.Now()
+	err = user.RegisterDevice(deviceId, data.UserAgent, data.Ip, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.SaveDevices(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	refreshToken, plain, err := domain.IssueRefreshToken(&domain.IssueRefreshTokenProps{
+		UuId:      uuid.NewString(),
+		FamilyId:  uuid.NewString(),
+		UserId:    user.Uuid(),
+		DeviceId:  deviceId,
+		ExpiresAt: now.Add(h.issuer.refreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.refreshTokens.Create(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.issuer.response(refreshToken, plain)
+}
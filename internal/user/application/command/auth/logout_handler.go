@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type LogoutHandler struct {
+	refreshTokens contract.RefreshTokenRepository
+}
+
+func NewLogoutHandler(refreshTokens contract.RefreshTokenRepository) *LogoutHandler {
+	return &LogoutHandler{refreshTokens: refreshTokens}
+}
+
+// Handle revokes the whole family, which also covers a logout sent with an
+// already rotated (and therefore possibly stolen) token.
+func (h *LogoutHandler) Handle(ctx context.Context, data *LogoutCommand) (any, error) {
+	current, err := findRefreshToken(ctx, h.refreshTokens, data.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, h.refreshTokens.RevokeFamily(ctx, current.FamilyId())
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+)
+
+type RefreshHandler struct {
+	repository    contract.UserRepository
+	refreshTokens contract.RefreshTokenRepository
+	issuer        *tokenIssuer
+}
+
+func NewRefreshHandler(
+	repository contract.UserRepository,
+	refreshTokens contract.RefreshTokenRepository,
+	accessTokens contract.AccessTokenIssuer,
+	refreshTokenTTL time.Duration,
+) *RefreshHandler {
+	return &RefreshHandler{
+		repository:    repository,
+		refreshTokens: refreshTokens,
+		issuer:        &tokenIssuer{accessTokens, refreshTokenTTL},
+	}
+}
+
+func (h *RefreshHandler) Handle(ctx context.Context, data *RefreshCommand) (*Response, error) {
+	current, err := findRefreshToken(ctx, h.refreshTokens, data.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.IsRevoked() {
+		return nil, errInvalidRefreshToken()
+	}
+
+	if current.IsReused() {
+		return nil, h.revokeReusedFamily(ctx, current.FamilyId())
+	}
+
+	now := time.Now()
+	if current.IsExpired(now) {
+		return nil, apperror.NewUnauthorizedError("refresh token expired")
+	}
+
+	next, plain, err := current.Rotate(uuid.NewString(), now.Add(h.issuer.refreshTokenTTL), now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.refreshTokens.Rotate(ctx, current, next)
+	var alreadyRotated *ddgo.AlreadyExistsError
+	if errors.As(err, &alreadyRotated) {
+		return nil, h.revokeReusedFamily(ctx, current.FamilyId())
+	}
+
+	var revokedDevice *ddgo.NotFoundError
+	if errors.As(err, &revokedDevice) {
+		return nil, errInvalidRefreshToken()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.touchDevice(ctx, next, data, now)
+	return h.issuer.response(next, plain)
+}
+
+// touchDevice updates the device last seen data. The tokens are rotated
+// already, so a failure is only logged.
+func (h *RefreshHandler) touchDevice(ctx context.Context, token *domain.RefreshToken, data *RefreshCommand, now time.Time) {
+	user, err := h.repository.FindByUuid(ctx, token.UserId())
+	if err == nil {
+		user.TouchDevice(token.DeviceId(), data.UserAgent, data.Ip, now)
+		err = h.repository.SaveDevices(ctx, user)
+	}
+
+	if err != nil {
+		slog.WarnContext(ctx, "device last seen not updated", "userId", token.UserId(), "deviceId", token.DeviceId(), "error", err)
+	}
+}
+
+func (h *RefreshHandler) revokeReusedFamily(ctx context.Context, familyId string) error {
+	slog.WarnContext(ctx, "refresh token reuse detected, revoking family", "familyId", familyId)
+	if err := h.refreshTokens.RevokeFamily(ctx, familyId); err != nil {
+		return err
+	}
+	return apperror.NewUnauthorizedError("refresh token reuse detected")
+}
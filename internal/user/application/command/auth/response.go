@@ -0,0 +1,12 @@
+package auth
+
+import "time"
+
+type Response struct {
+	AccessToken           string    `json:"accessToken"`
+	TokenType             string    `json:"tokenType"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+	RefreshToken          string    `json:"refreshToken"`
+	RefreshTokenExpiresAt time.Time `json:"refreshTokenExpiresAt"`
+	DeviceId              string    `json:"deviceId"`
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+)
+
+type tokenIssuer struct {
+	accessTokens    contract.AccessTokenIssuer
+	refreshTokenTTL time.Duration
+}
+
+func (i *tokenIssuer) response(refreshToken *domain.RefreshToken, plainRefreshToken string) (*Response, error) {
+	accessToken, expiresAt, err := i.accessTokens.Issue(refreshToken.UserId(), refreshToken.DeviceId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		AccessToken:           accessToken,
+		TokenType:             "Bearer",
+		ExpiresAt:             expiresAt,
+		RefreshToken:          plainRefreshToken,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt(),
+		DeviceId:              refreshToken.DeviceId(),
+	}, nil
+}
+
+func errInvalidCredentials() error {
+	return apperror.NewUnauthorizedError("invalid username or password")
+}
+
+func errInvalidRefreshToken() error {
+	return apperror.NewUnauthorizedError("invalid refresh token")
+}
+
+func findRefreshToken(ctx context.Context, refreshTokens contract.RefreshTokenRepository, plain string) (*domain.RefreshToken, error) {
+	token, err := refreshTokens.FindByHash(ctx, domain.HashRefreshToken(plain))
+
+	var notFound *ddgo.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, errInvalidRefreshToken()
+	}
+
+	return token, err
+}
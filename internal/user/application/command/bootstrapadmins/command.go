@@ -0,0 +1,13 @@
+package bootstrapadmins
+
+// AdminGroupId is the uuid of the admins group seeded by the
+// 20261017090000_seed_admin_group migration.
+const AdminGroupId = "00000000-0000-4000-8000-000000000001"
+
+type Command struct {
+	Usernames []string `json:"usernames"`
+}
+
+func (c *Command) Name() string {
+	return "bootstrapAdmins"
+}
@@ -0,0 +1,71 @@
+package bootstrapadmins
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	users       contract.UserRepository
+	groups      contract.GroupRepository
+	permissions contract.PermissionCache
+}
+
+func NewComandHandler(users contract.UserRepository, groups contract.GroupRepository, permissions contract.PermissionCache) *Handler {
+	return &Handler{
+		users:       users,
+		groups:      groups,
+		permissions: permissions,
+	}
+}
+
+// Handle adds the users to the admins group. A username not registered yet is
+// skipped, so the first admin can sign up and be granted on the next start.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	if len(data.Usernames) == 0 {
+		return nil, nil
+	}
+
+	group, err := h.groups.FindByUuid(ctx, AdminGroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := make([]string, 0, len(data.Usernames))
+	for _, username := range data.Usernames {
+		user, err := h.users.FindByUsername(ctx, username)
+
+		var notFound *ddgo.NotFoundError
+		if errors.As(err, &notFound) {
+			slog.WarnContext(ctx, "admin bootstrap skipped an unknown user", "username", username)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := group.AddMember(user.Uuid()); err != nil {
+			return nil, err
+		}
+		userIds = append(userIds, user.Uuid())
+	}
+
+	if len(userIds) == 0 {
+		return nil, nil
+	}
+
+	if err := h.groups.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	for _, userId := range userIds {
+		h.permissions.Invalidate(userId)
+	}
+
+	return nil, nil
+}
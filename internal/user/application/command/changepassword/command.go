@@ -0,0 +1,11 @@
+package changepassword
+
+type Command struct {
+	UserId          string `json:"userId"`
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+func (c *Command) Name() string {
+	return "changePassword"
+}
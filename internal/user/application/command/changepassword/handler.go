@@ -0,0 +1,62 @@
+package changepassword
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/password"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository    contract.UserRepository
+	passwords     *password.Service
+	refreshTokens contract.RefreshTokenRepository
+	permissions   contract.PermissionCache
+}
+
+func NewComandHandler(
+	repository contract.UserRepository,
+	passwords *password.Service,
+	refreshTokens contract.RefreshTokenRepository,
+	permissions contract.PermissionCache,
+) *Handler {
+	return &Handler{
+		repository:    repository,
+		passwords:     passwords,
+		refreshTokens: refreshTokens,
+		permissions:   permissions,
+	}
+}
+
+// Handle replaces the password when the current one is right and signs the
+// user out of every device, the caller logs in again.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := h.passwords.New(ctx, data.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.ChangePassword(data.CurrentPassword, h.passwords.Verifier(), next)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	err = h.refreshTokens.RevokeByUser(ctx, user.Uuid())
+	if err != nil {
+		return nil, err
+	}
+
+	h.permissions.Invalidate(user.Uuid())
+	return nil, nil
+}
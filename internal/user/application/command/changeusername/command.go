@@ -0,0 +1,11 @@
+package changeusername
+
+type Command struct {
+	UserId   string `json:"userId"`
+	Version  int    `json:"version"`
+	Username string `json:"username"`
+}
+
+func (c *Command) Name() string {
+	return "changeUsername"
+}
@@ -0,0 +1,47 @@
+package changeusername
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+)
+
+type Handler struct {
+	repository contract.UserRepository
+}
+
+func NewComandHandler(repository contract.UserRepository) *Handler {
+	return &Handler{repository: repository}
+}
+
+// Handle returns the user version after the change, the same one when the
+// username is already the requested one.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Version() != data.Version {
+		return nil, apperror.NewConflictError(fmt.Sprintf("user %s is at version %d", user.Uuid(), user.Version()))
+	}
+
+	err = user.ChangeUsername(data.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(user.DomainEvents()) == 0 {
+		return user.Version(), nil
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	user.ClearEvents()
+	return user.Version() + 1, nil
+}
@@ -0,0 +1,9 @@
+package creategroup
+
+type Command struct {
+	GroupName string `json:"groupName"`
+}
+
+func (c *Command) Name() string {
+	return "createGroup"
+}
@@ -0,0 +1,40 @@
+package creategroup
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Response struct {
+	Id string `json:"id"`
+}
+
+type Handler struct {
+	repository contract.GroupRepository
+}
+
+func NewComandHandler(repository contract.GroupRepository) *Handler {
+	return &Handler{
+		repository: repository,
+	}
+}
+
+func (h *Handler) Handle(ctx context.Context, data *Command) (*Response, error) {
+	group, err := domain.NewGroup(&domain.GroupProps{
+		UuId: uuid.NewString(),
+		Name: data.GroupName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Create(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{Id: group.Uuid()}, nil
+}
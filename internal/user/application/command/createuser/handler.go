@@ -2,62 +2,113 @@ package createuser
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jeffersonbrasilino/gomes/otel"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/password"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
 )
 
 type Handler struct {
 	repository    contract.UserRepository
+	passwords     *password.Service
+	agePolicy     *domain.AgePolicy
+	verification  *domain.VerificationPolicy
+	notifier      contract.Notifier
 	tracer        otel.OtelTrace
 	messageHeader map[string]string
 }
 
-func NewComandHandler(repository contract.UserRepository) *Handler {
+func NewComandHandler(
+	repository contract.UserRepository,
+	hasher contract.PasswordHasher,
+	breachedChecker contract.BreachedPasswordChecker,
+	agePolicy *domain.AgePolicy,
+	verification *domain.VerificationPolicy,
+	notifier contract.Notifier,
+) *Handler {
 	return &Handler{
-		repository: repository,
+		repository:   repository,
+		passwords:    password.NewService(hasher, breachedChecker),
+		agePolicy:    agePolicy,
+		verification: verification,
+		notifier:     notifier,
 	}
 }
 
 func (c *Handler) Handle(ctx context.Context, data *Command) (any, error) {
-	user, errAg := c.makeAggregate(data)
-	fmt.Println("data", user, errAg)
+	passwordHash, err := c.passwords.Hash(ctx, data.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, errAg := c.makeAggregate(data, passwordHash)
 	if errAg != nil {
 		return nil, errAg
 	}
 
-	err := c.repository.Create(ctx, user)
+	now := time.Now()
+	err = c.agePolicy.Validate(user.Person().BirthDate(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := user.RequestVerification(c.verification, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.repository.Create(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
+	// The repository stored the events in the outbox within the same
+	// transaction; the outbox relay publishes them from there.
+	user.ClearEvents()
+
+	// the user is registered already, a failed delivery is fixed by asking
+	// for the code again
+	err = c.notifier.SendVerificationCode(ctx, &contract.VerificationMessage{
+		UserId:    user.Uuid(),
+		To:        user.Person().Contact(user.Verification().ContactId()).Description(),
+		Code:      code,
+		ExpiresAt: user.Verification().ExpiresAt(),
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "verification code not delivered", "userId", user.Uuid(), "error", err)
+	}
+
 	return "okok", nil
 }
 
-func (c *Handler) makeAggregate(data *Command) (*domain.User, error) {
+func (c *Handler) makeAggregate(data *Command, passwordHash string) (*domain.User, error) {
 	return domain.NewBuilder().
 		WithUuId(uuid.NewString()).
-		WithPassword(data.Password).
+		WithPassword(passwordHash).
 		WithUsername(data.Username).
 		WithPerson(&domain.WithPersonProps{
 			Person: &domain.PersonProps{
-				UuId:      uuid.NewString(),
-				Name:      data.PersonName,
-				BirthDate: data.BirthDate,
+				UuId: uuid.NewString(),
+				Name: data.PersonName,
 			},
 			Document: &domain.DocumentProps{
 				Value: data.Document,
 			},
+			BirthDate: &domain.BirthDateProps{
+				Value: data.BirthDate,
+			},
 			Contacts: []*domain.ContactProps{
 				{
 					UuId:        uuid.NewString(),
-					ContactType: "email",
+					ContactType: string(domain.ContactTypeEmail),
 					Description: data.Email,
+					Main:        true,
 				},
 			},
 		}).
-		Build()
-}	
+		BuildNew()
+}
@@ -0,0 +1,62 @@
+package deactivateuser
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository    contract.UserRepository
+	refreshTokens contract.RefreshTokenRepository
+	permissions   contract.PermissionCache
+}
+
+func NewComandHandler(
+	repository contract.UserRepository,
+	refreshTokens contract.RefreshTokenRepository,
+	permissions contract.PermissionCache,
+) *Handler {
+	return &Handler{
+		repository:    repository,
+		refreshTokens: refreshTokens,
+		permissions:   permissions,
+	}
+}
+
+// Handle suspends the user and signs it out of every device.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.Deactivate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	err = h.refreshTokens.RevokeByUser(ctx, user.Uuid())
+	if err != nil {
+		return nil, err
+	}
+
+	h.permissions.Invalidate(user.Uuid())
+	return nil, nil
+}
+
+// AlreadyDeactivated tells the errors of a user that is already inactive or
+// deleted, deactivating it again has nothing left to do.
+func AlreadyDeactivated(err error) bool {
+	var notFound *ddgo.NotFoundError
+	return errors.Is(err, domain.ErrStatusTransition) || errors.As(err, &notFound)
+}
@@ -0,0 +1,9 @@
+package deletegroup
+
+type Command struct {
+	GroupId string `json:"groupId"`
+}
+
+func (c *Command) Name() string {
+	return "deleteGroup"
+}
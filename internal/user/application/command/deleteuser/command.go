@@ -0,0 +1,9 @@
+package deleteuser
+
+type Command struct {
+	UserId string `json:"userId"`
+}
+
+func (c *Command) Name() string {
+	return "deleteUser"
+}
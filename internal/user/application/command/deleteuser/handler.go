@@ -0,0 +1,54 @@
+package deleteuser
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository    contract.UserRepository
+	refreshTokens contract.RefreshTokenRepository
+	permissions   contract.PermissionCache
+}
+
+func NewComandHandler(
+	repository contract.UserRepository,
+	refreshTokens contract.RefreshTokenRepository,
+	permissions contract.PermissionCache,
+) *Handler {
+	return &Handler{
+		repository:    repository,
+		refreshTokens: refreshTokens,
+		permissions:   permissions,
+	}
+}
+
+// Handle soft deletes the user and signs it out of every device, its
+// personal data is anonymized once the retention period ends.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.Delete(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	err = h.refreshTokens.RevokeByUser(ctx, user.Uuid())
+	if err != nil {
+		return nil, err
+	}
+
+	h.permissions.Invalidate(user.Uuid())
+	return nil, nil
+}
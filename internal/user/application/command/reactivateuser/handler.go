@@ -0,0 +1,40 @@
+package reactivateuser
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository  contract.UserRepository
+	permissions contract.PermissionCache
+}
+
+func NewComandHandler(repository contract.UserRepository, permissions contract.PermissionCache) *Handler {
+	return &Handler{
+		repository:  repository,
+		permissions: permissions,
+	}
+}
+
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.Reactivate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	h.permissions.Invalidate(user.Uuid())
+	return nil, nil
+}
@@ -0,0 +1,10 @@
+package renamegroup
+
+type Command struct {
+	GroupId   string `json:"groupId"`
+	GroupName string `json:"groupName"`
+}
+
+func (c *Command) Name() string {
+	return "renameGroup"
+}
@@ -0,0 +1,42 @@
+package renamegroup
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository  contract.GroupRepository
+	permissions contract.PermissionCache
+}
+
+func NewComandHandler(repository contract.GroupRepository, permissions contract.PermissionCache) *Handler {
+	return &Handler{
+		repository:  repository,
+		permissions: permissions,
+	}
+}
+
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	group, err := h.repository.FindByUuid(ctx, data.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = group.Rename(data.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userId := range group.MemberIds() {
+		h.permissions.Invalidate(userId)
+	}
+
+	return nil, nil
+}
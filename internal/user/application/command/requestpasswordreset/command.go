@@ -0,0 +1,10 @@
+package requestpasswordreset
+
+// Command names the user by username or email, Login takes either.
+type Command struct {
+	Login string `json:"login"`
+}
+
+func (c *Command) Name() string {
+	return "requestPasswordReset"
+}
@@ -0,0 +1,90 @@
+package requestpasswordreset
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository contract.UserRepository
+	ttl        time.Duration
+	notifier   contract.Notifier
+}
+
+func NewComandHandler(repository contract.UserRepository, ttl time.Duration, notifier contract.Notifier) *Handler {
+	return &Handler{
+		repository: repository,
+		ttl:        ttl,
+		notifier:   notifier,
+	}
+}
+
+// Handle sends a reset token to the user's email. Unknown, inactive and
+// unreachable users are ignored without an error, the caller must not learn
+// which logins exist.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.find(ctx, strings.TrimSpace(data.Login))
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() || user.Email() == nil {
+		return nil, nil
+	}
+
+	token, err := user.RequestPasswordReset(h.ttl, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	err = h.notifier.SendPasswordReset(ctx, &contract.PasswordResetMessage{
+		UserId:    user.Uuid(),
+		To:        user.Email().Description(),
+		Token:     token,
+		ExpiresAt: user.PasswordReset().ExpiresAt(),
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "password reset not delivered", "userId", user.Uuid(), "error", err)
+	}
+
+	return nil, nil
+}
+
+func (h *Handler) find(ctx context.Context, login string) (*domain.User, error) {
+	if !strings.Contains(login, "@") {
+		return h.repository.FindByUsername(ctx, login)
+	}
+
+	email, err := domain.NormalizeEmail(login)
+	if err != nil {
+		return h.repository.FindByUsername(ctx, login)
+	}
+
+	user, err := h.repository.FindByEmail(ctx, email)
+	if isNotFound(err) {
+		return h.repository.FindByUsername(ctx, login)
+	}
+	return user, err
+}
+
+func isNotFound(err error) bool {
+	var notFound *ddgo.NotFoundError
+	return errors.As(err, &notFound)
+}
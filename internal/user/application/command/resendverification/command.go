@@ -0,0 +1,9 @@
+package resendverification
+
+type Command struct {
+	UserId string `json:"userId"`
+}
+
+func (c *Command) Name() string {
+	return "resendVerification"
+}
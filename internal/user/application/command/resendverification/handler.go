@@ -0,0 +1,51 @@
+package resendverification
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository contract.UserRepository
+	policy     *domain.VerificationPolicy
+	notifier   contract.Notifier
+}
+
+func NewComandHandler(repository contract.UserRepository, policy *domain.VerificationPolicy, notifier contract.Notifier) *Handler {
+	return &Handler{
+		repository: repository,
+		policy:     policy,
+		notifier:   notifier,
+	}
+}
+
+// Handle replaces the pending code with a new one, which also resets the
+// attempts.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := user.RequestVerification(h.policy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	verification := user.Verification()
+	return nil, h.notifier.SendVerificationCode(ctx, &contract.VerificationMessage{
+		UserId:    user.Uuid(),
+		To:        user.Person().Contact(verification.ContactId()).Description(),
+		Code:      code,
+		ExpiresAt: verification.ExpiresAt(),
+	})
+}
@@ -0,0 +1,10 @@
+package resetpassword
+
+type Command struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (c *Command) Name() string {
+	return "resetPassword"
+}
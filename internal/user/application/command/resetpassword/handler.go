@@ -0,0 +1,71 @@
+package resetpassword
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/password"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository    contract.UserRepository
+	passwords     *password.Service
+	refreshTokens contract.RefreshTokenRepository
+	permissions   contract.PermissionCache
+}
+
+func NewComandHandler(
+	repository contract.UserRepository,
+	passwords *password.Service,
+	refreshTokens contract.RefreshTokenRepository,
+	permissions contract.PermissionCache,
+) *Handler {
+	return &Handler{
+		repository:    repository,
+		passwords:     passwords,
+		refreshTokens: refreshTokens,
+		permissions:   permissions,
+	}
+}
+
+// Handle sets the new password with the reset token, which then stops
+// working, and signs the user out of every device.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByPasswordResetHash(ctx, domain.HashPasswordResetToken(data.Token))
+	var notFound *ddgo.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, domain.NewInvalidPasswordResetError()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := h.passwords.New(ctx, data.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.ResetPassword(data.Token, next, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	err = h.refreshTokens.RevokeByUser(ctx, user.Uuid())
+	if err != nil {
+		return nil, err
+	}
+
+	h.permissions.Invalidate(user.Uuid())
+	return nil, nil
+}
@@ -0,0 +1,10 @@
+package revokedevice
+
+type Command struct {
+	UserId   string `json:"userId"`
+	DeviceId string `json:"deviceId"`
+}
+
+func (c *Command) Name() string {
+	return "revokeDevice"
+}
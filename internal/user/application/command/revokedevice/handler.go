@@ -0,0 +1,42 @@
+package revokedevice
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository    contract.UserRepository
+	refreshTokens contract.RefreshTokenRepository
+}
+
+func NewComandHandler(repository contract.UserRepository, refreshTokens contract.RefreshTokenRepository) *Handler {
+	return &Handler{
+		repository:    repository,
+		refreshTokens: refreshTokens,
+	}
+}
+
+// Handle signs the user out of the device, its access tokens still work
+// until they expire.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.RevokeDevice(data.DeviceId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.SaveDevices(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	return nil, h.refreshTokens.RevokeByDevice(ctx, user.Uuid(), data.DeviceId)
+}
@@ -0,0 +1,11 @@
+package revokegrouppermission
+
+type Command struct {
+	GroupId  string `json:"groupId"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+func (c *Command) Name() string {
+	return "revokeGroupPermission"
+}
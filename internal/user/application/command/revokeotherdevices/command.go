@@ -0,0 +1,11 @@
+package revokeotherdevices
+
+// Command revokes every device of the user but CurrentDeviceId.
+type Command struct {
+	UserId          string `json:"userId"`
+	CurrentDeviceId string `json:"currentDeviceId"`
+}
+
+func (c *Command) Name() string {
+	return "revokeOtherDevices"
+}
@@ -0,0 +1,48 @@
+package revokeotherdevices
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository    contract.UserRepository
+	refreshTokens contract.RefreshTokenRepository
+}
+
+func NewComandHandler(repository contract.UserRepository, refreshTokens contract.RefreshTokenRepository) *Handler {
+	return &Handler{
+		repository:    repository,
+		refreshTokens: refreshTokens,
+	}
+}
+
+// Handle signs the user out of every device but the current one.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := user.RevokeOtherDevices(data.CurrentDeviceId, time.Now())
+	if len(revoked) == 0 {
+		return nil, nil
+	}
+
+	err = h.repository.SaveDevices(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ClearEvents()
+
+	for _, deviceId := range revoked {
+		err = h.refreshTokens.RevokeByDevice(ctx, user.Uuid(), deviceId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,36 @@
+package setusermaingroup
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository contract.GroupRepository
+}
+
+func NewComandHandler(repository contract.GroupRepository) *Handler {
+	return &Handler{
+		repository: repository,
+	}
+}
+
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	group, err := h.repository.FindByUuid(ctx, data.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = group.SetMainGroupFor(data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
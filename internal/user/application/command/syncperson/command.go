@@ -0,0 +1,11 @@
+package syncperson
+
+type Command struct {
+	PersonId   string `json:"personId"`
+	PersonName string `json:"name"`
+	BirthDate  string `json:"birthDate"`
+}
+
+func (c *Command) Name() string {
+	return "syncPerson"
+}
@@ -0,0 +1,36 @@
+package syncperson
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository contract.PersonRepository
+}
+
+func NewComandHandler(repository contract.PersonRepository) *Handler {
+	return &Handler{
+		repository: repository,
+	}
+}
+
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	person, err := h.repository.FindByUuid(ctx, data.PersonId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = person.ChangeDetails(data.PersonName, data.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.repository.Update(ctx, person)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,22 @@
+package updateuserprofile
+
+type Contact struct {
+	Id    string `json:"id"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Main  bool   `json:"main"`
+}
+
+// Command carries a merge patch of the profile: nil fields are kept and a non
+// nil Contacts replaces the whole list.
+type Command struct {
+	UserId     string     `json:"userId"`
+	Version    int        `json:"version"`
+	PersonName *string    `json:"name"`
+	BirthDate  *string    `json:"birthDate"`
+	Contacts   []*Contact `json:"contacts"`
+}
+
+func (c *Command) Name() string {
+	return "updateUserProfile"
+}
@@ -0,0 +1,71 @@
+package updateuserprofile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+)
+
+type Handler struct {
+	repository contract.UserRepository
+}
+
+func NewComandHandler(repository contract.UserRepository) *Handler {
+	return &Handler{repository: repository}
+}
+
+// Handle returns the user version after the update, the same one when nothing
+// changed.
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Version() != data.Version {
+		return nil, apperror.NewConflictError(fmt.Sprintf("user %s is at version %d", user.Uuid(), user.Version()))
+	}
+
+	changes := &domain.ProfileChanges{
+		Name:      data.PersonName,
+		BirthDate: data.BirthDate,
+	}
+
+	if data.Contacts != nil {
+		changes.Contacts = make([]*domain.ContactProps, 0, len(data.Contacts))
+		for _, contact := range data.Contacts {
+			id := contact.Id
+			if id == "" {
+				id = uuid.NewString()
+			}
+
+			changes.Contacts = append(changes.Contacts, &domain.ContactProps{
+				UuId:        id,
+				Description: contact.Value,
+				ContactType: contact.Type,
+				Main:        contact.Main,
+			})
+		}
+	}
+
+	err = user.UpdateProfile(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(user.DomainEvents()) == 0 {
+		return user.Version(), nil
+	}
+
+	err = h.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	user.ClearEvents()
+	return user.Version() + 1, nil
+}
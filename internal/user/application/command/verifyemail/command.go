@@ -0,0 +1,10 @@
+package verifyemail
+
+type Command struct {
+	UserId string `json:"userId"`
+	Code   string `json:"code"`
+}
+
+func (c *Command) Name() string {
+	return "verifyEmail"
+}
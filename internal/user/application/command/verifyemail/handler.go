@@ -0,0 +1,53 @@
+package verifyemail
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+type Handler struct {
+	repository contract.UserRepository
+	policy     *domain.VerificationPolicy
+}
+
+func NewComandHandler(repository contract.UserRepository, policy *domain.VerificationPolicy) *Handler {
+	return &Handler{
+		repository: repository,
+		policy:     policy,
+	}
+}
+
+func (h *Handler) Handle(ctx context.Context, data *Command) (any, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := 0
+	if user.Verification() != nil {
+		attempts = user.Verification().Attempts()
+	}
+
+	verifyErr := user.VerifyEmail(data.Code, h.policy, time.Now())
+	var invalid *ddgo.InvalidDataError
+	if verifyErr != nil && !errors.As(verifyErr, &invalid) {
+		return nil, verifyErr
+	}
+
+	// a wrong code still counts as an attempt
+	wrongCode := verifyErr != nil && user.Verification() != nil && user.Verification().Attempts() > attempts
+	if verifyErr == nil || wrongCode {
+		err = h.repository.Update(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		user.ClearEvents()
+	}
+
+	return nil, verifyErr
+}
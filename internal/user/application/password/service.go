@@ -0,0 +1,57 @@
+package password
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+// Service turns a chosen plain password into a stored one, the same rules
+// for registration, reset and change.
+type Service struct {
+	hasher          contract.PasswordHasher
+	breachedChecker contract.BreachedPasswordChecker
+	policy          *domain.PasswordPolicy
+}
+
+func NewService(hasher contract.PasswordHasher, breachedChecker contract.BreachedPasswordChecker) *Service {
+	return &Service{
+		hasher:          hasher,
+		breachedChecker: breachedChecker,
+		policy:          domain.DefaultPasswordPolicy(),
+	}
+}
+
+// Hash checks plain against the password policy and the breached list and
+// returns its hash.
+func (s *Service) Hash(ctx context.Context, plain string) (string, error) {
+	if err := s.policy.Validate(plain); err != nil {
+		return "", err
+	}
+
+	breached, err := s.breachedChecker.IsBreached(ctx, plain)
+	if err != nil {
+		return "", err
+	}
+
+	if breached {
+		return "", domain.NewBreachedPasswordError()
+	}
+
+	return s.hasher.Hash(plain)
+}
+
+// New hashes plain like Hash and wraps it in the domain value object.
+func (s *Service) New(ctx context.Context, plain string) (*domain.Password, error) {
+	hash, err := s.Hash(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPassword(&domain.PasswordProps{Hash: hash})
+}
+
+func (s *Service) Verifier() domain.PasswordVerifier {
+	return s.hasher
+}
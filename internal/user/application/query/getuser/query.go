@@ -1,12 +1,14 @@
 package getuser
 
 type Query struct {
-	DataSource    string
-	mapMockedData []float64
+	UserId string
+	// DataSource names the gateway the person is read from, empty keeps the
+	// person stored by this module.
+	DataSource string
 }
 
-func NewQuery() *Query {
-	return &Query{}
+func NewQuery(userId string) *Query {
+	return &Query{UserId: userId}
 }
 
 func (c *Query) Name() string {
@@ -2,43 +2,35 @@ package getuser
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
 )
 
 type QueryHandler struct {
+	repository contract.UserRepository
 	dataSource contract.UserDataSource
 }
 
-func NewQueryHandler(dataSource contract.UserDataSource) *QueryHandler {
-	return &QueryHandler{dataSource}
+func NewQueryHandler(repository contract.UserRepository, dataSource contract.UserDataSource) *QueryHandler {
+	return &QueryHandler{repository, dataSource}
 }
 
-func (h *QueryHandler) Handle(ctx context.Context, data *Query) (any, error) {
-	time.Sleep(time.Second * 1)
-	fmt.Println("get user > handle", data)
-	/* res, err := h.dataSource.WithGateway(data.DataSource).GetPerson()
+// Handle reads the person from the data source gateway named in the query,
+// or keeps the stored one when none is named.
+func (h *QueryHandler) Handle(ctx context.Context, data *Query) (*Response, error) {
+	user, err := h.repository.FindByUuid(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.DataSource == "" {
+		return newResponse(user, &contract.SourcedPerson{Person: user.Person(), Origin: storedOrigin}), nil
+	}
 
+	person, err := h.dataSource.WithGateway(data.DataSource).GetPerson(ctx, data.UserId)
 	if err != nil {
 		return nil, err
 	}
 
-	response := struct {
-		Id         string
-		Name       string
-		Age        int
-		BirthDate  string
-		Email      string
-		DataSource string
-	}{
-		res.Uuid(),
-		res.GetName(),
-		res.GetAge(),
-		res.GetBirthDate(),
-		res.GetEmail(),
-		res.GetDataSource(),
-	} */
-	return nil, fmt.Errorf("deu ruim")
+	return newResponse(user, person), nil
 }
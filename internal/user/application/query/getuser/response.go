@@ -0,0 +1,76 @@
+package getuser
+
+import (
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+// storedOrigin tags the person kept by this module.
+const storedOrigin = "local"
+
+type Response struct {
+	Id       string          `json:"id"`
+	Username string          `json:"username"`
+	Version  int             `json:"version"`
+	Status   string          `json:"status"`
+	Person   *PersonResponse `json:"person"`
+}
+
+type PersonResponse struct {
+	Id           string             `json:"id"`
+	Name         string             `json:"name"`
+	BirthDate    string             `json:"birthDate"`
+	Document     string             `json:"document"`
+	DocumentType string             `json:"documentType"`
+	Contacts     []*ContactResponse `json:"contacts"`
+	Origin       string             `json:"origin"`
+	FetchedAt    *time.Time         `json:"fetchedAt,omitempty"`
+}
+
+type ContactResponse struct {
+	Id          string     `json:"id"`
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	Main        bool       `json:"main"`
+	VerifiedAt  *time.Time `json:"verifiedAt,omitempty"`
+}
+
+func newResponse(user *domain.User, sourced *contract.SourcedPerson) *Response {
+	person := sourced.Person
+	contacts := make([]*ContactResponse, 0, len(person.Contacts()))
+	for _, contact := range person.Contacts() {
+		contacts = append(contacts, &ContactResponse{
+			Id:          contact.Uuid(),
+			Type:        string(contact.ContactType()),
+			Description: contact.Description(),
+			Main:        contact.Main(),
+			VerifiedAt:  contact.VerifiedAt(),
+		})
+	}
+
+	return &Response{
+		Id:       user.Uuid(),
+		Username: user.Username(),
+		Version:  user.Version(),
+		Status:   string(user.Status()),
+		Person: &PersonResponse{
+			Id:           person.Uuid(),
+			Name:         person.Name(),
+			BirthDate:    person.BirthDate().Value(),
+			Document:     person.Document().Value(),
+			DocumentType: string(person.Document().Type()),
+			Contacts:     contacts,
+			Origin:       sourced.Origin,
+			FetchedAt:    fetchedAt(sourced),
+		},
+	}
+}
+
+func fetchedAt(sourced *contract.SourcedPerson) *time.Time {
+	if sourced.FetchedAt.IsZero() {
+		return nil
+	}
+	return &sourced.FetchedAt
+}
@@ -0,0 +1,34 @@
+package listdevices
+
+import (
+	"context"
+	"time"
+)
+
+// Reader is the read side of devices. ListActive returns the devices of the
+// user that are not revoked and still hold a usable refresh token at now,
+// most recently seen first.
+type Reader interface {
+	ListActive(ctx context.Context, userId string, now time.Time) ([]*Item, error)
+}
+
+type QueryHandler struct {
+	reader Reader
+}
+
+func NewQueryHandler(reader Reader) *QueryHandler {
+	return &QueryHandler{reader}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, data *Query) (*Response, error) {
+	items, err := h.reader.ListActive(ctx, data.UserId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		item.Current = item.DeviceId == data.CurrentDeviceId
+	}
+
+	return &Response{Items: items}, nil
+}
@@ -0,0 +1,12 @@
+package listdevices
+
+// Query lists the active devices of a user. CurrentDeviceId marks the device
+// the request came from, if any.
+type Query struct {
+	UserId          string
+	CurrentDeviceId string
+}
+
+func (c *Query) Name() string {
+	return "listDevices"
+}
@@ -0,0 +1,17 @@
+package listdevices
+
+import "time"
+
+type Response struct {
+	Items []*Item `json:"items"`
+}
+
+type Item struct {
+	DeviceId         string    `json:"deviceId"`
+	UserAgent        string    `json:"userAgent"`
+	LastIp           string    `json:"lastIp"`
+	LastSeenAt       time.Time `json:"lastSeenAt"`
+	RegisteredAt     time.Time `json:"registeredAt"`
+	SessionExpiresAt time.Time `json:"sessionExpiresAt"`
+	Current          bool      `json:"current"`
+}
@@ -0,0 +1,73 @@
+package listusers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type SortKey string
+
+const (
+	SortByCreatedAt SortKey = "createdAt"
+	SortByUsername  SortKey = "username"
+)
+
+// Filter is the validated Query the Reader runs.
+type Filter struct {
+	UsernamePrefix string
+	Document       string
+	GroupId        string
+	Status         string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	Sort           SortKey
+	Descending     bool
+	After          *Cursor
+	Offset         int
+	Limit          int
+}
+
+// Cursor points at the last item of a page: its sort value and id, the id
+// breaking ties between equal values.
+type Cursor struct {
+	Sort       SortKey `json:"s"`
+	Descending bool    `json:"d"`
+	Value      string  `json:"v"`
+	Id         string  `json:"i"`
+}
+
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.Sort == SortByCreatedAt {
+		if _, err := time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+			return nil, err
+		}
+	}
+	return &cursor, nil
+}
+
+func parseSort(value string) (SortKey, bool, bool) {
+	if value == "" {
+		return SortByCreatedAt, true, true
+	}
+
+	descending := strings.HasPrefix(value, "-")
+	key := SortKey(strings.TrimPrefix(value, "-"))
+	return key, descending, key == SortByCreatedAt || key == SortByUsername
+}
@@ -0,0 +1,144 @@
+package listusers
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+// Reader is the read side of users: it returns flat rows instead of
+// rebuilding aggregates. List returns up to filter.Limit items and the total
+// of users matching the filter, ignoring pagination.
+type Reader interface {
+	List(ctx context.Context, filter *Filter) ([]*Item, int64, error)
+}
+
+type QueryHandler struct {
+	reader Reader
+}
+
+func NewQueryHandler(reader Reader) *QueryHandler {
+	return &QueryHandler{reader}
+}
+
+func (h *QueryHandler) Handle(ctx context.Context, data *Query) (*Response, error) {
+	filter, err := newFilter(data)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	// one more row tells whether there is a next page
+	filter.Limit++
+	items, total, err := h.reader.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{Items: items, Total: total}
+	if len(items) > limit {
+		response.Items = items[:limit]
+		last := response.Items[limit-1]
+		response.NextCursor = (&Cursor{
+			Sort:       filter.Sort,
+			Descending: filter.Descending,
+			Value:      last.sortValue(filter.Sort),
+			Id:         last.Id,
+		}).Encode()
+	}
+
+	return response, nil
+}
+
+func newFilter(data *Query) (*Filter, error) {
+	invalid := map[string][]string{}
+	sort, descending, ok := parseSort(data.Sort)
+	if !ok {
+		invalid["Sort"] = []string{"oneof"}
+	}
+
+	statuses := []string{"", string(domain.UserStatusActive), string(domain.UserStatusInactive)}
+	if !slices.Contains(statuses, data.Status) {
+		invalid["Status"] = []string{"oneof"}
+	}
+
+	limit := data.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 1 || limit > MaxLimit {
+		invalid["Limit"] = []string{"range"}
+	}
+
+	if data.Offset < 0 {
+		invalid["Offset"] = []string{"gte"}
+	}
+
+	if data.CreatedFrom != nil && data.CreatedTo != nil && data.CreatedTo.Before(*data.CreatedFrom) {
+		invalid["CreatedTo"] = []string{"gtefield"}
+	}
+
+	document := data.Document
+	if document != "" {
+		parsed, err := domain.NewDocument(&domain.DocumentProps{Value: document})
+		if err != nil {
+			invalid["Document"] = []string{"document"}
+		} else {
+			document = parsed.Value()
+		}
+	}
+
+	var after *Cursor
+	if data.Cursor != "" {
+		cursor, err := decodeCursor(data.Cursor)
+		// a cursor only continues the listing it came from
+		if err != nil || cursor.Sort != sort || cursor.Descending != descending {
+			invalid["Cursor"] = []string{"cursor"}
+		}
+		after = cursor
+	}
+
+	if len(invalid) > 0 {
+		return nil, invalidData(invalid)
+	}
+
+	offset := data.Offset
+	if after != nil {
+		offset = 0
+	}
+
+	return &Filter{
+		UsernamePrefix: data.UsernamePrefix,
+		Document:       document,
+		GroupId:        data.GroupId,
+		Status:         data.Status,
+		CreatedFrom:    data.CreatedFrom,
+		CreatedTo:      data.CreatedTo,
+		Sort:           sort,
+		Descending:     descending,
+		After:          after,
+		Offset:         offset,
+		Limit:          limit,
+	}, nil
+}
+
+func invalidData(fields map[string][]string) error {
+	type fieldValidation struct {
+		IsValid          bool
+		FailedValidators []string
+	}
+
+	result := make(map[string]fieldValidation, len(fields))
+	for field, validators := range fields {
+		result[field] = fieldValidation{IsValid: false, FailedValidators: validators}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return ddgo.NewInternalError("Error when marshaling validation errors")
+	}
+	return ddgo.NewInvalidDataError(string(data))
+}
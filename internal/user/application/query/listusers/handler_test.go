@@ -0,0 +1,118 @@
+package listusers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listusers"
+)
+
+type fakeReader struct {
+	items   []*listusers.Item
+	filters []*listusers.Filter
+}
+
+// List pages the items, already sorted by username, the way the database
+// reader does for SortByUsername.
+func (r *fakeReader) List(ctx context.Context, filter *listusers.Filter) ([]*listusers.Item, int64, error) {
+	r.filters = append(r.filters, filter)
+	start := filter.Offset
+	if filter.After != nil {
+		for i, item := range r.items {
+			if item.Username == filter.After.Value {
+				start = i + 1
+			}
+		}
+	}
+
+	end := min(start+filter.Limit, len(r.items))
+	return r.items[start:end], int64(len(r.items)), nil
+}
+
+func newFakeReader(count int) *fakeReader {
+	reader := &fakeReader{}
+	for i := range count {
+		reader.items = append(reader.items, &listusers.Item{
+			Id:        fmt.Sprintf("user-%d", i),
+			Username:  fmt.Sprintf("user%02d", i),
+			CreatedAt: time.Now(),
+		})
+	}
+	return reader
+}
+
+func TestListUsers(t *testing.T) {
+	t.Run("Should walk every page with the next cursor", func(t *testing.T) {
+		t.Parallel()
+		reader := newFakeReader(5)
+		handler := listusers.NewQueryHandler(reader)
+
+		seen := 0
+		cursor := ""
+		for page := 0; page < 5; page++ {
+			res, err := handler.Handle(context.Background(), &listusers.Query{Sort: "username", Limit: 2, Cursor: cursor})
+			if err != nil {
+				t.Fatalf("Should list users, got: %v", err)
+			}
+
+			if res.Total != 5 {
+				t.Errorf("Should return total 5, got: %d", res.Total)
+			}
+
+			seen += len(res.Items)
+			cursor = res.NextCursor
+			if cursor == "" {
+				break
+			}
+		}
+
+		if seen != 5 {
+			t.Errorf("Should list 5 users, got: %d", seen)
+		}
+	})
+
+	t.Run("Should apply the default limit and ignore the offset with a cursor", func(t *testing.T) {
+		t.Parallel()
+		reader := newFakeReader(3)
+		handler := listusers.NewQueryHandler(reader)
+		first, _ := handler.Handle(context.Background(), &listusers.Query{Sort: "username", Limit: 1})
+
+		_, err := handler.Handle(context.Background(), &listusers.Query{Sort: "username", Cursor: first.NextCursor, Offset: 2})
+		if err != nil {
+			t.Fatalf("Should list users, got: %v", err)
+		}
+
+		filter := reader.filters[1]
+		if filter.Offset != 0 || filter.Limit != listusers.DefaultLimit+1 {
+			t.Errorf("Should ask offset 0 and limit %d, got: %d and %d", listusers.DefaultLimit+1, filter.Offset, filter.Limit)
+		}
+	})
+
+	tests := []struct {
+		description string
+		query       *listusers.Query
+	}{
+		{"Should return error for unknown sort key", &listusers.Query{Sort: "password"}},
+		{"Should return error for unknown status", &listusers.Query{Status: "deleted"}},
+		{"Should return error for limit over the maximum", &listusers.Query{Limit: listusers.MaxLimit + 1}},
+		{"Should return error for malformed cursor", &listusers.Query{Cursor: "not-a-cursor"}},
+		{"Should return error for cursor of another sort", &listusers.Query{
+			Sort:   "-createdAt",
+			Cursor: (&listusers.Cursor{Sort: listusers.SortByUsername, Value: "user01", Id: "user-1"}).Encode(),
+		}},
+		{"Should return error for invalid document", &listusers.Query{Document: "123"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			_, err := listusers.NewQueryHandler(newFakeReader(1)).Handle(context.Background(), tt.query)
+			if _, ok := err.(*ddgo.InvalidDataError); !ok {
+				t.Errorf("Should return InvalidDataError, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package listusers
+
+import "time"
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Query lists users page by page. Cursor and Offset are alternatives: when a
+// cursor is sent the offset is ignored.
+type Query struct {
+	UsernamePrefix string
+	Document       string
+	GroupId        string
+	Status         string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	// Sort is one of the sort keys, prefixed with "-" for descending order.
+	Sort   string
+	Cursor string
+	Offset int
+	Limit  int
+}
+
+func (c *Query) Name() string {
+	return "listUsers"
+}
@@ -0,0 +1,27 @@
+package listusers
+
+import "time"
+
+type Response struct {
+	Items      []*Item `json:"items"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+	Total      int64   `json:"total"`
+}
+
+type Item struct {
+	Id         string    `json:"id"`
+	Username   string    `json:"username"`
+	Status     string    `json:"status"`
+	PersonId   string    `json:"personId"`
+	PersonName string    `json:"personName"`
+	Document   string    `json:"document"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// sortValue is the cursor value of the item for the given sort key.
+func (i *Item) sortValue(key SortKey) string {
+	if key == SortByUsername {
+		return i.Username
+	}
+	return i.CreatedAt.UTC().Format(time.RFC3339Nano)
+}
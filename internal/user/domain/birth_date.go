@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+const birthDateLayout = "2006-01-02"
+
+// birthDateLayouts are tried in order: ISO-8601 and the pt_BR dd/mm/yyyy.
+var birthDateLayouts = []string{birthDateLayout, "02/01/2006"}
+
+type BirthDateProps struct {
+	Value string `domainValidator:"required"`
+}
+
+// BirthDate is a calendar date without time zone, kept at UTC midnight.
+type BirthDate struct {
+	value time.Time
+}
+
+func NewBirthDate(props *BirthDateProps) (*BirthDate, error) {
+	err := validateBirthDate(props)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := parseBirthDate(strings.TrimSpace(props.Value))
+	if !ok {
+		return nil, newFieldError("Value", "date")
+	}
+
+	if value.After(time.Now().UTC()) {
+		return nil, newFieldError("Value", "past")
+	}
+
+	return &BirthDate{value: value}, nil
+}
+
+func validateBirthDate(props *BirthDateProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating birth date data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+func parseBirthDate(value string) (time.Time, bool) {
+	for _, layout := range birthDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Value returns the ISO-8601 date, ex: 1990-05-21.
+func (b *BirthDate) Value() string {
+	return b.value.Format(birthDateLayout)
+}
+
+func (b *BirthDate) Time() time.Time {
+	return b.value
+}
+
+// Age counts the birthdays reached until now. People born on February 29
+// turn a year older on March 1 in common years.
+func (b *BirthDate) Age(now time.Time) int {
+	now = now.UTC()
+	age := now.Year() - b.value.Year()
+	if now.Month() < b.value.Month() || (now.Month() == b.value.Month() && now.Day() < b.value.Day()) {
+		age--
+	}
+	return age
+}
+
+type AgePolicy struct {
+	MinimumAge int
+}
+
+func DefaultAgePolicy() *AgePolicy {
+	return &AgePolicy{MinimumAge: 18}
+}
+
+func (p *AgePolicy) Validate(birthDate *BirthDate, now time.Time) error {
+	if birthDate.Age(now) < p.MinimumAge {
+		return newFieldError("BirthDate", "minimumAge")
+	}
+	return nil
+}
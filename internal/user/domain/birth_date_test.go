@@ -0,0 +1,115 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+func newTestBirthDate(value string) *domain.BirthDate {
+	birthDate, _ := domain.NewBirthDate(&domain.BirthDateProps{Value: value})
+	return birthDate
+}
+
+func TestNewBirthDate(t *testing.T) {
+	valid := []struct {
+		description string
+		value       string
+	}{
+		{"Should parse an ISO-8601 date", "1990-05-21"},
+		{"Should parse a pt_BR date", "21/05/1990"},
+		{"Should ignore surrounding spaces", " 1990-05-21 "},
+	}
+
+	for _, c := range valid {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			birthDate, err := domain.NewBirthDate(&domain.BirthDateProps{Value: c.value})
+			if err != nil {
+				t.Fatalf("Should return a birth date, got: %v", err)
+			}
+
+			if birthDate.Value() != "1990-05-21" {
+				t.Errorf("Should return 1990-05-21, got: %s", birthDate.Value())
+			}
+		})
+	}
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	invalid := []struct {
+		description string
+		value       string
+		expected    string
+	}{
+		{"Should fail without a value", "", `{"Value":{"IsValid":false,"FailedValidators":["required"]}}`},
+		{"Should fail when the value is not a date", "banana", `{"Value":{"IsValid":false,"FailedValidators":["date"]}}`},
+		{"Should fail when the day does not exist", "1990-02-30", `{"Value":{"IsValid":false,"FailedValidators":["date"]}}`},
+		{"Should fail with a mm/dd/yyyy date", "05/21/1990", `{"Value":{"IsValid":false,"FailedValidators":["date"]}}`},
+		{"Should fail with a future date", tomorrow, `{"Value":{"IsValid":false,"FailedValidators":["past"]}}`},
+	}
+
+	for _, c := range invalid {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			birthDate, err := domain.NewBirthDate(&domain.BirthDateProps{Value: c.value})
+			if birthDate != nil {
+				t.Error("Should return an error, got birth date")
+			}
+
+			if err == nil || err.Error() != c.expected {
+				t.Errorf("Should return %s, got: %v", c.expected, err)
+			}
+		})
+	}
+}
+
+func TestBirthDateAge(t *testing.T) {
+	cases := []struct {
+		description string
+		birthDate   string
+		now         time.Time
+		expected    int
+	}{
+		{"Should count a birthday reached this year", "1990-05-21", time.Date(2026, 5, 21, 0, 0, 0, 0, time.UTC), 36},
+		{"Should not count a birthday still to come", "1990-05-21", time.Date(2026, 5, 20, 23, 59, 0, 0, time.UTC), 35},
+		{"Should age a leap day birth on March 1 of common years", "2004-02-29", time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), 17},
+		{"Should age a leap day birth on March 1 of common years", "2004-02-29", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), 18},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			if age := newTestBirthDate(c.birthDate).Age(c.now); age != c.expected {
+				t.Errorf("Should return %d, got: %d", c.expected, age)
+			}
+		})
+	}
+}
+
+func TestAgePolicyValidate(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should accept a person at the minimum age", func(t *testing.T) {
+		t.Parallel()
+		if err := domain.DefaultAgePolicy().Validate(newTestBirthDate("2008-10-16"), now); err != nil {
+			t.Errorf("Should accept, got: %v", err)
+		}
+	})
+
+	t.Run("Should reject a person under the minimum age", func(t *testing.T) {
+		t.Parallel()
+		err := domain.DefaultAgePolicy().Validate(newTestBirthDate("2008-10-17"), now)
+		if err == nil || err.Error() != `{"BirthDate":{"IsValid":false,"FailedValidators":["minimumAge"]}}` {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+	})
+
+	t.Run("Should follow a configured minimum age", func(t *testing.T) {
+		t.Parallel()
+		policy := &domain.AgePolicy{MinimumAge: 13}
+		if err := policy.Validate(newTestBirthDate("2013-10-16"), now); err != nil {
+			t.Errorf("Should accept, got: %v", err)
+		}
+	})
+}
@@ -4,22 +4,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jeffersonbrasilino/ddgo"
 )
 
 type Builder struct {
-	buildErrors []string
-	uuId        string
-	username    string
-	password    string
-	person      *Person
+	buildErrors   []string
+	uuId          string
+	username      string
+	password      string
+	person        *Person
+	version       int
+	status        UserStatus
+	deletedAt     *time.Time
+	verification  *VerificationCode
+	passwordReset *PasswordReset
+	devices       []*Device
 }
 
 type WithPersonProps struct {
-	Person   *PersonProps
-	Document *DocumentProps
-	Contacts []*ContactProps
+	Person    *PersonProps
+	Document  *DocumentProps
+	BirthDate *BirthDateProps
+	Contacts  []*ContactProps
 }
 
 func NewBuilder() *Builder {
@@ -38,8 +46,66 @@ func (b *Builder) WithUsername(username string) *Builder {
 	return b
 }
 
-func (b *Builder) WithPassword(password string) *Builder {
-	b.password = password
+func (b *Builder) WithVersion(version int) *Builder {
+	b.version = version
+	return b
+}
+
+func (b *Builder) WithStatus(status UserStatus, deletedAt *time.Time) *Builder {
+	b.status = status
+	b.deletedAt = deletedAt
+	return b
+}
+
+// WithVerification sets the pending email verification, nil props mean none.
+func (b *Builder) WithVerification(props *VerificationCodeProps) *Builder {
+	if props == nil {
+		return b
+	}
+
+	code, err := NewVerificationCode(props)
+	if err != nil {
+		b.buildErrors = append(b.buildErrors, fmt.Sprintf("verification: %s", err.Error()))
+		return b
+	}
+
+	b.verification = code
+	return b
+}
+
+// WithPasswordReset sets the pending password reset, nil props mean none.
+func (b *Builder) WithPasswordReset(props *PasswordResetProps) *Builder {
+	if props == nil {
+		return b
+	}
+
+	reset, err := NewPasswordReset(props)
+	if err != nil {
+		b.buildErrors = append(b.buildErrors, fmt.Sprintf("passwordReset: %s", err.Error()))
+		return b
+	}
+
+	b.passwordReset = reset
+	return b
+}
+
+func (b *Builder) WithDevices(props []*DeviceProps) *Builder {
+	devices := make([]*Device, 0, len(props))
+	for _, deviceProps := range props {
+		device, err := NewDevice(deviceProps)
+		if err != nil {
+			b.buildErrors = append(b.buildErrors, fmt.Sprintf("device: %s", err.Error()))
+			continue
+		}
+		devices = append(devices, device)
+	}
+
+	b.devices = devices
+	return b
+}
+
+func (b *Builder) WithPassword(passwordHash string) *Builder {
+	b.password = passwordHash
 	return b
 }
 
@@ -61,6 +127,14 @@ func (b *Builder) WithPerson(personProps *WithPersonProps) *Builder {
 		props.Document = doc
 	}
 
+	if personProps.BirthDate != nil {
+		birthDate, err := NewBirthDate(personProps.BirthDate)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		props.BirthDate = birthDate
+	}
+
 	if personProps.Contacts != nil {
 		contacts := make([]*Contact, 0, len(personProps.Contacts))
 		for _, contact := range personProps.Contacts {
@@ -88,6 +162,26 @@ func (b *Builder) WithPerson(personProps *WithPersonProps) *Builder {
 }
 
 func (b *Builder) Build() (*User, error) {
+	props, err := b.props()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUser(props)
+}
+
+// BuildNew builds a user that is being registered, recording its UserCreated
+// event.
+func (b *Builder) BuildNew() (*User, error) {
+	props, err := b.props()
+	if err != nil {
+		return nil, err
+	}
+
+	return RegisterUser(props)
+}
+
+func (b *Builder) props() (*UserProps, error) {
 	if len(b.buildErrors) > 0 {
 		validationResult, failed := json.Marshal(b.buildErrors)
 		if failed != nil {
@@ -96,10 +190,21 @@ func (b *Builder) Build() (*User, error) {
 		return nil, ddgo.NewInvalidDataError(string(validationResult))
 	}
 
-	return NewUser(&UserProps{
-		UuId:     b.uuId,
-		Username: b.username,
-		Password: b.password,
-		Person:   b.person,
-	})
+	password, err := NewPassword(&PasswordProps{Hash: b.password})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserProps{
+		UuId:          b.uuId,
+		Username:      b.username,
+		Password:      password,
+		Person:        b.person,
+		Version:       b.version,
+		Status:        b.status,
+		DeletedAt:     b.deletedAt,
+		Verification:  b.verification,
+		PasswordReset: b.passwordReset,
+		Devices:       b.devices,
+	}, nil
 }
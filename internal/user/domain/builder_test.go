@@ -9,9 +9,8 @@ import (
 func validPersonProps() *domain.WithPersonProps {
 	return &domain.WithPersonProps{
 		Person: &domain.PersonProps{
-			UuId:      "person-uuid-1",
-			Name:      "John Doe",
-			BirthDate: "1990-01-01",
+			UuId: "person-uuid-1",
+			Name: "John Doe",
 		},
 		Contacts: []*domain.ContactProps{
 			{
@@ -21,7 +20,10 @@ func validPersonProps() *domain.WithPersonProps {
 			},
 		},
 		Document: &domain.DocumentProps{
-			Value: "123.456.789-00",
+			Value: "529.982.247-25",
+		},
+		BirthDate: &domain.BirthDateProps{
+			Value: "1990-01-01",
 		},
 	}
 }
@@ -2,21 +2,46 @@ package domain
 
 import (
 	"encoding/json"
+	"net/mail"
+	"strings"
+	"time"
 
 	"github.com/jeffersonbrasilino/ddgo"
 )
 
+type ContactType string
+
+const (
+	ContactTypeEmail    ContactType = "email"
+	ContactTypeMobile   ContactType = "mobile"
+	ContactTypePhone    ContactType = "phone"
+	ContactTypeWhatsapp ContactType = "whatsapp"
+)
+
+// defaultCountryCode completes national phone numbers, the service keeps
+// Brazilian data.
+const defaultCountryCode = "55"
+
+// ContactTypes lists the supported types, the same rows seeded into
+// person_contacts_types.
+func ContactTypes() []ContactType {
+	return []ContactType{ContactTypeEmail, ContactTypeMobile, ContactTypePhone, ContactTypeWhatsapp}
+}
+
 type ContactProps struct {
 	UuId        string `domainValidator:"required"`
 	Description string `domainValidator:"required"`
 	ContactType string `domainValidator:"required"`
+	Main        bool
+	VerifiedAt  *time.Time
 }
 
 type Contact struct {
 	*ddgo.Entity
-	uuId        string
 	description string
-	contactType string
+	contactType ContactType
+	main        bool
+	verifiedAt  *time.Time
 }
 
 func NewContact(props *ContactProps) (*Contact, error) {
@@ -24,9 +49,18 @@ func NewContact(props *ContactProps) (*Contact, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	contactType := ContactType(props.ContactType)
+	description, err := normalizeContact(contactType, props.Description)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Contact{
-		description: props.Description,
-		contactType: props.ContactType,
+		description: description,
+		contactType: contactType,
+		main:        props.Main,
+		verifiedAt:  props.VerifiedAt,
 		Entity:      ddgo.NewEntity(props.UuId),
 	}, nil
 }
@@ -49,10 +83,107 @@ func validateContact(props *ContactProps) error {
 	return nil
 }
 
+func normalizeContact(contactType ContactType, description string) (string, error) {
+	switch contactType {
+	case ContactTypeEmail:
+		return NormalizeEmail(description)
+	case ContactTypeMobile, ContactTypePhone, ContactTypeWhatsapp:
+		return normalizePhone(description)
+	default:
+		return "", newFieldError("ContactType", "contactType")
+	}
+}
+
+// NormalizeEmail accepts a bare RFC 5322 address and lowercases its domain,
+// the local part is case sensitive. Emails are stored in this form, lookups
+// must use it as well.
+func NormalizeEmail(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value || address.Name != "" {
+		return "", newFieldError("Description", "email")
+	}
+
+	at := strings.LastIndex(value, "@")
+	return value[:at] + strings.ToLower(value[at:]), nil
+}
+
+// normalizePhone returns the E.164 form, +<country><number>. National
+// numbers (DDD + number) get defaultCountryCode.
+func normalizePhone(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	international := strings.HasPrefix(value, "+") || strings.HasPrefix(value, "00")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "+"), "00")
+
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", newFieldError("Description", "phone")
+		}
+	}
+
+	number := digits.String()
+	if !international {
+		number = strings.TrimPrefix(number, "0")
+		if len(number) != 10 && len(number) != 11 {
+			return "", newFieldError("Description", "phone")
+		}
+		number = defaultCountryCode + number
+	}
+
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", newFieldError("Description", "phone")
+	}
+	return "+" + number, nil
+}
+
 func (c *Contact) Description() string {
 	return c.description
 }
 
-func (c *Contact) ContactType() string {
+func (c *Contact) ContactType() ContactType {
 	return c.contactType
 }
+
+// Main tells whether this is the person's preferred contact, a person has
+// exactly one when it has any contact.
+func (c *Contact) Main() bool {
+	return c.main
+}
+
+// VerifiedAt is when the person proved owning the contact, nil while it is
+// not verified.
+func (c *Contact) VerifiedAt() *time.Time {
+	return c.verifiedAt
+}
+
+func (c *Contact) Verified() bool {
+	return c.verifiedAt != nil
+}
+
+// withIdentityOf returns a copy that keeps the uuid and verification of an
+// equal contact already stored.
+func (c *Contact) withIdentityOf(stored *Contact) *Contact {
+	copied := *c
+	copied.Entity = ddgo.NewEntity(stored.Uuid())
+	copied.verifiedAt = stored.verifiedAt
+	return &copied
+}
+
+func (c *Contact) withVerifiedAt(verifiedAt time.Time) *Contact {
+	copied := *c
+	copied.verifiedAt = &verifiedAt
+	return &copied
+}
+
+// withMain returns a copy, contacts are shared with the caller that built
+// the person.
+func (c *Contact) withMain(main bool) *Contact {
+	copied := *c
+	copied.main = main
+	return &copied
+}
@@ -7,76 +7,91 @@ import (
 )
 
 func TestNewContact(t *testing.T) {
-	t.Run("Should success when create contact with valid data", func(t *testing.T) {
-		t.Parallel()
-		props := &domain.ContactProps{
-			UuId:        "1",
-			Description: "1",
-			ContactType: "1",
-		}
-
-		contact, err := domain.NewContact(props)
-		if err != nil {
-			t.Errorf("Should return a contact, got: %v", err)
-		}
-
-		if contact == nil {
-			t.Error("Should return a contact, got nil")
-		}
-	})
-
-	t.Run("Should fail when create contact with invalid data", func(t *testing.T) {
-		t.Parallel()
-		props := &domain.ContactProps{
-			UuId:        "",
-			Description: "",
-			ContactType: "",
-		}
-
-		contact, err := domain.NewContact(props)
-		if err == nil {
-			t.Errorf("Should return an error, got: %v", err)
-		}
-
-		if contact != nil {
-			t.Error("Should return an error, got contact")
-		}
+	valid := []struct {
+		description string
+		contactType domain.ContactType
+		value       string
+		expected    string
+	}{
+		{"Should keep a valid email", domain.ContactTypeEmail, "john.doe+news@example.com", "john.doe+news@example.com"},
+		{"Should lowercase the email domain only", domain.ContactTypeEmail, " John@Example.COM ", "John@example.com"},
+		{"Should keep an E.164 mobile", domain.ContactTypeMobile, "+55 11 98765-4321", "+5511987654321"},
+		{"Should add the country code to a national mobile", domain.ContactTypeMobile, "(11) 98765-4321", "+5511987654321"},
+		{"Should drop the trunk prefix of a national phone", domain.ContactTypePhone, "0 11 3456-7890", "+551134567890"},
+		{"Should accept the 00 international prefix", domain.ContactTypeWhatsapp, "00 1 415 555 2671", "+14155552671"},
+	}
 
-		if err.Error() != `{"ContactType":{"IsValid":false,"FailedValidators":["required"]},"Description":{"IsValid":false,"FailedValidators":["required"]},"UuId":{"IsValid":false,"FailedValidators":["required"]}}` {
-			t.Errorf("Should return an error, got: %v", err)
-		}
-	})
-}
+	for _, c := range valid {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			contact, err := domain.NewContact(&domain.ContactProps{
+				UuId:        "1",
+				Description: c.value,
+				ContactType: string(c.contactType),
+				Main:        true,
+			})
+			if err != nil {
+				t.Fatalf("Should return a contact, got: %v", err)
+			}
 
-func TestContactGetProps(t *testing.T) {
-	props := &domain.ContactProps{
-		UuId:        "1",
-		Description: "1",
-		ContactType: "1",
+			if contact.Description() != c.expected || contact.ContactType() != c.contactType || !contact.Main() {
+				t.Errorf("Should return %s %s main, got: %s %s %v", c.contactType, c.expected, contact.ContactType(), contact.Description(), contact.Main())
+			}
+		})
 	}
-	contact, _ := domain.NewContact(props)
-	var cases = []struct {
+
+	invalid := []struct {
 		description string
-		getFunc     func() any
-		expected    any
+		props       *domain.ContactProps
+		expected    string
 	}{
 		{
-			description: "Should return the contact description",
-			getFunc:     func() any { return contact.Description() },
-			expected:    props.Description,
+			"Should fail without data",
+			&domain.ContactProps{},
+			`{"ContactType":{"IsValid":false,"FailedValidators":["required"]},"Description":{"IsValid":false,"FailedValidators":["required"]},"UuId":{"IsValid":false,"FailedValidators":["required"]}}`,
+		},
+		{
+			"Should fail with an unknown contact type",
+			&domain.ContactProps{UuId: "1", Description: "john@example.com", ContactType: "fax"},
+			`{"ContactType":{"IsValid":false,"FailedValidators":["contactType"]}}`,
+		},
+		{
+			"Should fail with an invalid email",
+			&domain.ContactProps{UuId: "1", Description: "john@", ContactType: "email"},
+			`{"Description":{"IsValid":false,"FailedValidators":["email"]}}`,
+		},
+		{
+			"Should fail with an email carrying a display name",
+			&domain.ContactProps{UuId: "1", Description: "John <john@example.com>", ContactType: "email"},
+			`{"Description":{"IsValid":false,"FailedValidators":["email"]}}`,
 		},
 		{
-			description: "Should return the contact type",
-			getFunc:     func() any { return contact.ContactType() },
-			expected:    props.ContactType,
+			"Should fail with letters in a phone",
+			&domain.ContactProps{UuId: "1", Description: "+55 11 9876-ABCD", ContactType: "phone"},
+			`{"Description":{"IsValid":false,"FailedValidators":["phone"]}}`,
+		},
+		{
+			"Should fail with a short national phone",
+			&domain.ContactProps{UuId: "1", Description: "98765-4321", ContactType: "mobile"},
+			`{"Description":{"IsValid":false,"FailedValidators":["phone"]}}`,
+		},
+		{
+			"Should fail with an international phone longer than E.164 allows",
+			&domain.ContactProps{UuId: "1", Description: "+1234567890123456", ContactType: "whatsapp"},
+			`{"Description":{"IsValid":false,"FailedValidators":["phone"]}}`,
 		},
 	}
 
-	for _, c := range cases {
+	for _, c := range invalid {
 		t.Run(c.description, func(t *testing.T) {
 			t.Parallel()
-			if c.getFunc() != c.expected {
-				t.Errorf("Should return %v, got: %v", c.expected, c.getFunc())
+			contact, err := domain.NewContact(c.props)
+			if contact != nil {
+				t.Error("Should return an error, got contact")
+			}
+
+			if err == nil || err.Error() != c.expected {
+				t.Errorf("Should return %s, got: %v", c.expected, err)
 			}
 		})
 	}
@@ -0,0 +1,7 @@
+package contract
+
+import "time"
+
+type AccessTokenIssuer interface {
+	Issue(subject string, deviceId string) (string, time.Time, error)
+}
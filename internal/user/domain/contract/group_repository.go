@@ -0,0 +1,14 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+type GroupRepository interface {
+	Create(ctx context.Context, aggregate *domain.Group) error
+	FindByUuid(ctx context.Context, uuid string) (*domain.Group, error)
+	Update(ctx context.Context, aggregate *domain.Group) error
+	Delete(ctx context.Context, aggregate *domain.Group) error
+}
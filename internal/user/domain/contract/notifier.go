@@ -0,0 +1,26 @@
+package contract
+
+import (
+	"context"
+	"time"
+)
+
+type VerificationMessage struct {
+	UserId    string
+	To        string
+	Code      string
+	ExpiresAt time.Time
+}
+
+type PasswordResetMessage struct {
+	UserId    string
+	To        string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// Notifier delivers messages to the person behind a user.
+type Notifier interface {
+	SendVerificationCode(ctx context.Context, message *VerificationMessage) error
+	SendPasswordReset(ctx context.Context, message *PasswordResetMessage) error
+}
@@ -0,0 +1,16 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+type PasswordHasher interface {
+	domain.PasswordVerifier
+	Hash(plain string) (string, error)
+}
+
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, plain string) (bool, error)
+}
@@ -0,0 +1,5 @@
+package contract
+
+type PermissionCache interface {
+	Invalidate(userId string)
+}
@@ -0,0 +1,12 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+type PersonRepository interface {
+	FindByUuid(ctx context.Context, uuid string) (*domain.Person, error)
+	Update(ctx context.Context, person *domain.Person) error
+}
@@ -0,0 +1,16 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	Rotate(ctx context.Context, current *domain.RefreshToken, next *domain.RefreshToken) error
+	RevokeFamily(ctx context.Context, familyId string) error
+	RevokeByUser(ctx context.Context, userId string) error
+	RevokeByDevice(ctx context.Context, userId string, deviceId string) error
+}
@@ -8,4 +8,14 @@ import (
 
 type UserRepository interface {
 	Create(ctx context.Context, aggregate *domain.User) error
+	FindByUuid(ctx context.Context, uuid string) (*domain.User, error)
+	FindByUsername(ctx context.Context, username string) (*domain.User, error)
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindByPasswordResetHash(ctx context.Context, hash string) (*domain.User, error)
+	// Update returns apperror.ConflictError when the stored user or person
+	// version is not the one they were loaded with.
+	Update(ctx context.Context, aggregate *domain.User) error
+	// SaveDevices saves only the changed devices and the recorded events,
+	// without the version check of Update.
+	SaveDevices(ctx context.Context, aggregate *domain.User) error
 }
@@ -1,8 +1,28 @@
 package contract
 
-import "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+import (
+	"context"
+	"time"
 
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+// SourcedPerson is a person together with the gateway it came from.
+type SourcedPerson struct {
+	Person    *domain.Person
+	Origin    string
+	FetchedAt time.Time
+}
+
+// PersonGateway looks the person of a user up in one data source.
+type PersonGateway interface {
+	Name() string
+	GetPerson(ctx context.Context, userId string) (*domain.Person, error)
+}
+
+// UserDataSource reads the person of a user from the gateway selected with
+// WithGateway, or from the default one.
 type UserDataSource interface {
-	GetPerson() (*domain.Person, error)
+	GetPerson(ctx context.Context, userId string) (*SourcedPerson, error)
 	WithGateway(gateway string) UserDataSource
 }
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+type DeviceProps struct {
+	DeviceId     string `domainValidator:"required,lte=255"`
+	UserAgent    string
+	LastIp       string
+	LastSeenAt   time.Time
+	RegisteredAt time.Time
+	RevokedAt    *time.Time
+}
+
+// Device is a client the user logged in from, identified by the device id
+// the client sends (or gets) on login. Its refresh tokens are its session.
+type Device struct {
+	*ddgo.Entity
+	userAgent    string
+	lastIp       string
+	lastSeenAt   time.Time
+	registeredAt time.Time
+	revokedAt    *time.Time
+}
+
+func NewDevice(props *DeviceProps) (*Device, error) {
+	err := validateDevice(props)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Device{
+		Entity:       ddgo.NewEntity(props.DeviceId),
+		userAgent:    props.UserAgent,
+		lastIp:       props.LastIp,
+		lastSeenAt:   props.LastSeenAt,
+		registeredAt: props.RegisteredAt,
+		revokedAt:    props.RevokedAt,
+	}, nil
+}
+
+func validateDevice(props *DeviceProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating device data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+// DeviceId is the identity of the device, the same as Uuid.
+func (d *Device) DeviceId() string {
+	return d.Uuid()
+}
+
+func (d *Device) UserAgent() string {
+	return d.userAgent
+}
+
+func (d *Device) LastIp() string {
+	return d.lastIp
+}
+
+func (d *Device) LastSeenAt() time.Time {
+	return d.lastSeenAt
+}
+
+func (d *Device) RegisteredAt() time.Time {
+	return d.registeredAt
+}
+
+func (d *Device) RevokedAt() *time.Time {
+	return d.revokedAt
+}
+
+// IsActive tells whether the device may still hold a session.
+func (d *Device) IsActive() bool {
+	return d.revokedAt == nil
+}
+
+func (d *Device) seen(userAgent string, ip string, now time.Time) {
+	d.userAgent = userAgent
+	d.lastIp = ip
+	d.lastSeenAt = now
+}
+
+func (d *Device) revoke(now time.Time) {
+	d.revokedAt = &now
+}
@@ -2,16 +2,27 @@ package domain
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/jeffersonbrasilino/ddgo"
 )
 
+type DocumentType string
+
+const (
+	DocumentTypeCPF  DocumentType = "cpf"
+	DocumentTypeCNPJ DocumentType = "cnpj"
+)
+
 type DocumentProps struct {
 	Value string `domainValidator:"required"`
 }
 
+// Document is a Brazilian CPF or CNPJ, kept unmasked. CNPJ accepts the
+// alphanumeric format, where the first 12 characters may be letters.
 type Document struct {
-	value string
+	value        string
+	documentType DocumentType
 }
 
 func NewDocument(props *DocumentProps) (*Document, error) {
@@ -19,8 +30,16 @@ func NewDocument(props *DocumentProps) (*Document, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	value := normalizeDocument(props.Value)
+	documentType, err := detectDocumentType(value)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Document{
-		value: props.Value,
+		value:        value,
+		documentType: documentType,
 	}, nil
 }
 
@@ -28,7 +47,7 @@ func validateDocument(props *DocumentProps) error {
 	validator := ddgo.ValidatorInstance()
 	validationErrors, faliedValidation := validator.Validate(props)
 	if faliedValidation != nil {
-		return ddgo.NewInternalError("Error when validating contact data")
+		return ddgo.NewInternalError("Error when validating document data")
 	}
 
 	if len(validationErrors) > 0 {
@@ -42,6 +61,129 @@ func validateDocument(props *DocumentProps) error {
 	return nil
 }
 
+// normalizeDocument drops the mask punctuation and uppercases CNPJ letters.
+func normalizeDocument(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', '/', ' ', '\t':
+			return -1
+		}
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}, value)
+}
+
+func detectDocumentType(value string) (DocumentType, error) {
+	switch {
+	case len(value) == 11 && isDigits(value):
+		if !validCPF(value) {
+			return "", newFieldError("Value", "cpf")
+		}
+		return DocumentTypeCPF, nil
+	case len(value) == 14 && isAlphanumeric(value[:12]) && isDigits(value[12:]):
+		if !validCNPJ(value) {
+			return "", newFieldError("Value", "cnpj")
+		}
+		return DocumentTypeCNPJ, nil
+	default:
+		return "", newFieldError("Value", "document")
+	}
+}
+
+func validCPF(value string) bool {
+	if isRepeated(value) {
+		return false
+	}
+
+	digits := make([]int, len(value))
+	for i, r := range value {
+		digits[i] = int(r - '0')
+	}
+
+	for _, size := range []int{9, 10} {
+		sum := 0
+		for i := 0; i < size; i++ {
+			sum += digits[i] * (size + 1 - i)
+		}
+
+		check := sum * 10 % 11
+		if check == 10 {
+			check = 0
+		}
+		if check != digits[size] {
+			return false
+		}
+	}
+	return true
+}
+
+// validCNPJ follows the Receita Federal rule for both formats: every
+// character is worth its ASCII code minus 48, so digits keep their value.
+func validCNPJ(value string) bool {
+	if isRepeated(value) {
+		return false
+	}
+
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for _, size := range []int{12, 13} {
+		sum := 0
+		for i := 0; i < size; i++ {
+			sum += int(value[i]-'0') * weights[len(weights)-size+i]
+		}
+
+		check := 0
+		if rest := sum % 11; rest >= 2 {
+			check = 11 - rest
+		}
+		if check != int(value[size]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(value string) bool {
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isRepeated(value string) bool {
+	return strings.Count(value, value[:1]) == len(value)
+}
+
+// Value returns the unmasked document, the form it is stored and compared in.
 func (d *Document) Value() string {
 	return d.value
 }
+
+func (d *Document) Type() DocumentType {
+	return d.documentType
+}
+
+func (d *Document) Unmasked() string {
+	return d.value
+}
+
+// Masked renders the document as 000.000.000-00 (CPF) or 00.000.000/0000-00 (CNPJ).
+func (d *Document) Masked() string {
+	v := d.value
+	if d.documentType == DocumentTypeCPF {
+		return v[:3] + "." + v[3:6] + "." + v[6:9] + "-" + v[9:]
+	}
+	return v[:2] + "." + v[2:5] + "." + v[5:8] + "/" + v[8:12] + "-" + v[12:]
+}
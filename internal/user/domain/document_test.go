@@ -7,65 +7,68 @@ import (
 )
 
 func TestNewDocument(t *testing.T) {
-	t.Run("Should success when create document with valid data", func(t *testing.T) {
-		t.Parallel()
-		props := &domain.DocumentProps{
-			Value: "1",
-		}
-
-		document, err := domain.NewDocument(props)
-		if err != nil {
-			t.Errorf("Should return a document, got: %v", err)
-		}
-
-		if document == nil {
-			t.Error("Should return a document, got nil")
-		}
-	})
-
-	t.Run("Should fail when create document with invalid data", func(t *testing.T) {
-		t.Parallel()
-		props := &domain.DocumentProps{
-			Value: "",
-		}
+	valid := []struct {
+		description  string
+		value        string
+		documentType domain.DocumentType
+		unmasked     string
+		masked       string
+	}{
+		{"Should accept a masked CPF", "529.982.247-25", domain.DocumentTypeCPF, "52998224725", "529.982.247-25"},
+		{"Should accept an unmasked CPF", "52998224725", domain.DocumentTypeCPF, "52998224725", "529.982.247-25"},
+		{"Should accept a masked CNPJ", "11.222.333/0001-81", domain.DocumentTypeCNPJ, "11222333000181", "11.222.333/0001-81"},
+		{"Should accept an unmasked CNPJ", "11222333000181", domain.DocumentTypeCNPJ, "11222333000181", "11.222.333/0001-81"},
+		{"Should accept an alphanumeric CNPJ", "12.ABC.345/01DE-35", domain.DocumentTypeCNPJ, "12ABC34501DE35", "12.ABC.345/01DE-35"},
+		{"Should uppercase an alphanumeric CNPJ", "12abc34501de35", domain.DocumentTypeCNPJ, "12ABC34501DE35", "12.ABC.345/01DE-35"},
+	}
 
-		document, err := domain.NewDocument(props)
-		if err == nil {
-			t.Errorf("Should return an error, got: %v", err)
-		}
+	for _, c := range valid {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			document, err := domain.NewDocument(&domain.DocumentProps{Value: c.value})
+			if err != nil {
+				t.Fatalf("Should return a document, got: %v", err)
+			}
 
-		if document != nil {
-			t.Error("Should return an error, got document")
-		}
+			if document.Type() != c.documentType {
+				t.Errorf("Should detect %s, got: %s", c.documentType, document.Type())
+			}
 
-		if err.Error() != `{"Value":{"IsValid":false,"FailedValidators":["required"]}}` {
-			t.Errorf("Should return an error, got: %v", err)
-		}
-	})
-}
+			if document.Value() != c.unmasked || document.Unmasked() != c.unmasked {
+				t.Errorf("Should return unmasked %s, got: %s", c.unmasked, document.Value())
+			}
 
-func TestDocumentGetProps(t *testing.T) {
-	props := &domain.DocumentProps{
-		Value: "1",
+			if document.Masked() != c.masked {
+				t.Errorf("Should return masked %s, got: %s", c.masked, document.Masked())
+			}
+		})
 	}
-	document, _ := domain.NewDocument(props)
-	var cases = []struct {
+
+	invalid := []struct {
 		description string
-		getFunc     func() any
-		expected    any
+		value       string
+		expected    string
 	}{
-		{
-			description: "Should return the document value",
-			getFunc:     func() any { return document.Value() },
-			expected:    props.Value,
-		},
+		{"Should fail without a value", "", `{"Value":{"IsValid":false,"FailedValidators":["required"]}}`},
+		{"Should fail when the CPF check digits are wrong", "529.982.247-26", `{"Value":{"IsValid":false,"FailedValidators":["cpf"]}}`},
+		{"Should fail when the CPF is a repeated sequence", "111.111.111-11", `{"Value":{"IsValid":false,"FailedValidators":["cpf"]}}`},
+		{"Should fail when the CNPJ check digits are wrong", "11.222.333/0001-82", `{"Value":{"IsValid":false,"FailedValidators":["cnpj"]}}`},
+		{"Should fail when the CNPJ is a repeated sequence", "00.000.000/0000-00", `{"Value":{"IsValid":false,"FailedValidators":["cnpj"]}}`},
+		{"Should fail when the CNPJ check digits are letters", "12ABC34501DEAB", `{"Value":{"IsValid":false,"FailedValidators":["document"]}}`},
+		{"Should fail when the length matches no document", "1234567890", `{"Value":{"IsValid":false,"FailedValidators":["document"]}}`},
+		{"Should fail when a CPF has letters", "5299822472A", `{"Value":{"IsValid":false,"FailedValidators":["document"]}}`},
 	}
 
-	for _, c := range cases {
+	for _, c := range invalid {
 		t.Run(c.description, func(t *testing.T) {
 			t.Parallel()
-			if c.getFunc() != c.expected {
-				t.Errorf("Should return %v, got: %v", c.expected, c.getFunc())
+			document, err := domain.NewDocument(&domain.DocumentProps{Value: c.value})
+			if document != nil {
+				t.Error("Should return an error, got document")
+			}
+
+			if err == nil || err.Error() != c.expected {
+				t.Errorf("Should return %s, got: %v", c.expected, err)
 			}
 		})
 	}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"encoding/json"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+type fieldValidation struct {
+	IsValid          bool
+	FailedValidators []string
+}
+
+// newFieldError reports rules that the tag based validator cannot express
+// using the same JSON shape it produces, so clients see a single format.
+func newFieldError(field string, failedValidators ...string) error {
+	validationResult, failed := json.Marshal(map[string]fieldValidation{
+		field: {IsValid: false, FailedValidators: failedValidators},
+	})
+	if failed != nil {
+		return ddgo.NewInternalError("Error when marshaling validation errors")
+	}
+	return ddgo.NewInvalidDataError(string(validationResult))
+}
+
+// ErrStatusTransition is returned when the user can not go from its status to
+// the requested one, ex: deactivating a user that is already inactive.
+var ErrStatusTransition = newFieldError("Status", "transition")
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeviceRegisteredPayload struct {
+	UserId   string `json:"userId"`
+	DeviceId string `json:"deviceId"`
+}
+
+type DeviceRegistered struct {
+	eventId    string
+	occurredOn time.Time
+	payload    DeviceRegisteredPayload
+}
+
+func NewDeviceRegistered(userId string, deviceId string) *DeviceRegistered {
+	return &DeviceRegistered{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: DeviceRegisteredPayload{
+			UserId:   userId,
+			DeviceId: deviceId,
+		},
+	}
+}
+
+func (e *DeviceRegistered) Name() string {
+	return "deviceRegistered"
+}
+
+func (e *DeviceRegistered) Payload() any {
+	return e.payload
+}
+
+func (e *DeviceRegistered) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *DeviceRegistered) Uuid() string {
+	return e.eventId
+}
+
+func (e *DeviceRegistered) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
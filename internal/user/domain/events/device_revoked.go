@@ -0,0 +1,50 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeviceRevokedPayload struct {
+	UserId   string `json:"userId"`
+	DeviceId string `json:"deviceId"`
+}
+
+type DeviceRevoked struct {
+	eventId    string
+	occurredOn time.Time
+	payload    DeviceRevokedPayload
+}
+
+func NewDeviceRevoked(userId string, deviceId string) *DeviceRevoked {
+	return &DeviceRevoked{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: DeviceRevokedPayload{
+			UserId:   userId,
+			DeviceId: deviceId,
+		},
+	}
+}
+
+func (e *DeviceRevoked) Name() string {
+	return "deviceRevoked"
+}
+
+func (e *DeviceRevoked) Payload() any {
+	return e.payload
+}
+
+func (e *DeviceRevoked) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *DeviceRevoked) Uuid() string {
+	return e.eventId
+}
+
+func (e *DeviceRevoked) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
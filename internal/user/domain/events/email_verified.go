@@ -0,0 +1,50 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailVerifiedPayload struct {
+	UserId    string `json:"userId"`
+	ContactId string `json:"contactId"`
+}
+
+type EmailVerified struct {
+	eventId    string
+	occurredOn time.Time
+	payload    EmailVerifiedPayload
+}
+
+func NewEmailVerified(userId string, contactId string) *EmailVerified {
+	return &EmailVerified{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: EmailVerifiedPayload{
+			UserId:    userId,
+			ContactId: contactId,
+		},
+	}
+}
+
+func (e *EmailVerified) Name() string {
+	return "emailVerified"
+}
+
+func (e *EmailVerified) Payload() any {
+	return e.payload
+}
+
+func (e *EmailVerified) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *EmailVerified) Uuid() string {
+	return e.eventId
+}
+
+func (e *EmailVerified) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
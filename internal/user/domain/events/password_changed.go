@@ -0,0 +1,55 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PasswordChangedByUser  = "change"
+	PasswordChangedByReset = "reset"
+)
+
+type PasswordChangedPayload struct {
+	UserId string `json:"userId"`
+	Reason string `json:"reason"`
+}
+
+type PasswordChanged struct {
+	eventId    string
+	occurredOn time.Time
+	payload    PasswordChangedPayload
+}
+
+func NewPasswordChanged(userId string, reason string) *PasswordChanged {
+	return &PasswordChanged{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: PasswordChangedPayload{
+			UserId: userId,
+			Reason: reason,
+		},
+	}
+}
+
+func (e *PasswordChanged) Name() string {
+	return "passwordChanged"
+}
+
+func (e *PasswordChanged) Payload() any {
+	return e.payload
+}
+
+func (e *PasswordChanged) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *PasswordChanged) Uuid() string {
+	return e.eventId
+}
+
+func (e *PasswordChanged) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
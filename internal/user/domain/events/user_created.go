@@ -1,23 +1,52 @@
 package events
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserCreatedPayload struct {
+	UserId   string `json:"userId"`
+	Username string `json:"username"`
+	PersonId string `json:"personId"`
+}
 
 type UserCreated struct {
-	UserCreatedId string
+	eventId    string
+	occurredOn time.Time
+	payload    UserCreatedPayload
 }
 
-func NewUserCreated(userCreatedId string) *UserCreated {
-	return &UserCreated{UserCreatedId: "123456"}
+func NewUserCreated(userId string, username string, personId string) *UserCreated {
+	return &UserCreated{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: UserCreatedPayload{
+			UserId:   userId,
+			Username: username,
+			PersonId: personId,
+		},
+	}
+}
+
+func (e *UserCreated) Name() string {
+	return "userCreated"
 }
 
 func (e *UserCreated) Payload() any {
-	return "UserCreated PAYLOAD"
+	return e.payload
 }
 
 func (e *UserCreated) OcurredOn() time.Time {
-	return time.Now()
+	return e.occurredOn
 }
 
 func (e *UserCreated) Uuid() string {
-	return "hueheuhueh"
+	return e.eventId
+}
+
+func (e *UserCreated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
 }
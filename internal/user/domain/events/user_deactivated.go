@@ -0,0 +1,46 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserDeactivatedPayload struct {
+	UserId string `json:"userId"`
+}
+
+type UserDeactivated struct {
+	eventId    string
+	occurredOn time.Time
+	payload    UserDeactivatedPayload
+}
+
+func NewUserDeactivated(userId string) *UserDeactivated {
+	return &UserDeactivated{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload:    UserDeactivatedPayload{UserId: userId},
+	}
+}
+
+func (e *UserDeactivated) Name() string {
+	return "userDeactivated"
+}
+
+func (e *UserDeactivated) Payload() any {
+	return e.payload
+}
+
+func (e *UserDeactivated) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *UserDeactivated) Uuid() string {
+	return e.eventId
+}
+
+func (e *UserDeactivated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
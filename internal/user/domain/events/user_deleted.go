@@ -0,0 +1,46 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserDeletedPayload struct {
+	UserId string `json:"userId"`
+}
+
+type UserDeleted struct {
+	eventId    string
+	occurredOn time.Time
+	payload    UserDeletedPayload
+}
+
+func NewUserDeleted(userId string) *UserDeleted {
+	return &UserDeleted{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload:    UserDeletedPayload{UserId: userId},
+	}
+}
+
+func (e *UserDeleted) Name() string {
+	return "userDeleted"
+}
+
+func (e *UserDeleted) Payload() any {
+	return e.payload
+}
+
+func (e *UserDeleted) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *UserDeleted) Uuid() string {
+	return e.eventId
+}
+
+func (e *UserDeleted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
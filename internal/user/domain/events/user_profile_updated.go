@@ -0,0 +1,54 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserProfileUpdatedPayload struct {
+	UserId   string   `json:"userId"`
+	PersonId string   `json:"personId"`
+	Changes  []string `json:"changes"`
+}
+
+// UserProfileUpdated lists the profile fields that changed, ex: name,
+// birthDate, contacts. Consumers read the current values from the user.
+type UserProfileUpdated struct {
+	eventId    string
+	occurredOn time.Time
+	payload    UserProfileUpdatedPayload
+}
+
+func NewUserProfileUpdated(userId string, personId string, changes []string) *UserProfileUpdated {
+	return &UserProfileUpdated{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: UserProfileUpdatedPayload{
+			UserId:   userId,
+			PersonId: personId,
+			Changes:  changes,
+		},
+	}
+}
+
+func (e *UserProfileUpdated) Name() string {
+	return "userProfileUpdated"
+}
+
+func (e *UserProfileUpdated) Payload() any {
+	return e.payload
+}
+
+func (e *UserProfileUpdated) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *UserProfileUpdated) Uuid() string {
+	return e.eventId
+}
+
+func (e *UserProfileUpdated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
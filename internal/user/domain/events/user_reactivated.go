@@ -0,0 +1,46 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserReactivatedPayload struct {
+	UserId string `json:"userId"`
+}
+
+type UserReactivated struct {
+	eventId    string
+	occurredOn time.Time
+	payload    UserReactivatedPayload
+}
+
+func NewUserReactivated(userId string) *UserReactivated {
+	return &UserReactivated{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload:    UserReactivatedPayload{UserId: userId},
+	}
+}
+
+func (e *UserReactivated) Name() string {
+	return "userReactivated"
+}
+
+func (e *UserReactivated) Payload() any {
+	return e.payload
+}
+
+func (e *UserReactivated) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *UserReactivated) Uuid() string {
+	return e.eventId
+}
+
+func (e *UserReactivated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UsernameChangedPayload struct {
+	UserId           string `json:"userId"`
+	PreviousUsername string `json:"previousUsername"`
+	Username         string `json:"username"`
+}
+
+type UsernameChanged struct {
+	eventId    string
+	occurredOn time.Time
+	payload    UsernameChangedPayload
+}
+
+func NewUsernameChanged(userId string, previousUsername string, username string) *UsernameChanged {
+	return &UsernameChanged{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: UsernameChangedPayload{
+			UserId:           userId,
+			PreviousUsername: previousUsername,
+			Username:         username,
+		},
+	}
+}
+
+func (e *UsernameChanged) Name() string {
+	return "usernameChanged"
+}
+
+func (e *UsernameChanged) Payload() any {
+	return e.payload
+}
+
+func (e *UsernameChanged) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *UsernameChanged) Uuid() string {
+	return e.eventId
+}
+
+func (e *UsernameChanged) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
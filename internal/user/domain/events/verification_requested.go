@@ -0,0 +1,54 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VerificationRequestedPayload leaves the code out, it only travels through
+// the notifier.
+type VerificationRequestedPayload struct {
+	UserId    string    `json:"userId"`
+	ContactId string    `json:"contactId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type VerificationRequested struct {
+	eventId    string
+	occurredOn time.Time
+	payload    VerificationRequestedPayload
+}
+
+func NewVerificationRequested(userId string, contactId string, expiresAt time.Time) *VerificationRequested {
+	return &VerificationRequested{
+		eventId:    uuid.NewString(),
+		occurredOn: time.Now().UTC(),
+		payload: VerificationRequestedPayload{
+			UserId:    userId,
+			ContactId: contactId,
+			ExpiresAt: expiresAt.UTC(),
+		},
+	}
+}
+
+func (e *VerificationRequested) Name() string {
+	return "verificationRequested"
+}
+
+func (e *VerificationRequested) Payload() any {
+	return e.payload
+}
+
+func (e *VerificationRequested) OcurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *VerificationRequested) Uuid() string {
+	return e.eventId
+}
+
+func (e *VerificationRequested) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+type GroupProps struct {
+	UuId        string `domainValidator:"required"`
+	Name        string `domainValidator:"required"`
+	Permissions []*GroupPermission
+	Members     []*GroupMember
+}
+
+type Group struct {
+	*ddgo.AggregateRoot
+	name        string
+	permissions []*GroupPermission
+	members     []*GroupMember
+}
+
+func NewGroup(props *GroupProps) (*Group, error) {
+	err := validateGroup(props)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Group{
+		AggregateRoot: ddgo.NewAggregateRoot(props.UuId),
+		name:          props.Name,
+		permissions:   props.Permissions,
+		members:       props.Members,
+	}, nil
+}
+
+func validateGroup(props *GroupProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating group data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+func (g *Group) Name() string {
+	return g.name
+}
+
+func (g *Group) Permissions() []*GroupPermission {
+	return g.permissions
+}
+
+func (g *Group) Members() []*GroupMember {
+	return g.members
+}
+
+func (g *Group) MemberIds() []string {
+	ids := make([]string, 0, len(g.members))
+	for _, member := range g.members {
+		ids = append(ids, member.UserId())
+	}
+	return ids
+}
+
+func (g *Group) Rename(name string) error {
+	if name == "" {
+		return newFieldError("Name", "required")
+	}
+	g.name = name
+	return nil
+}
+
+// GrantPermission is idempotent: granting an action twice keeps a single entry.
+func (g *Group) GrantPermission(props *GroupPermissionProps) error {
+	permission, err := NewGroupPermission(props)
+	if err != nil {
+		return err
+	}
+
+	if g.hasPermission(permission) {
+		return nil
+	}
+
+	g.permissions = append(g.permissions, permission)
+	return nil
+}
+
+func (g *Group) RevokePermission(props *GroupPermissionProps) error {
+	permission, err := NewGroupPermission(props)
+	if err != nil {
+		return err
+	}
+
+	g.permissions = slices.DeleteFunc(g.permissions, func(current *GroupPermission) bool {
+		return current.Equals(permission)
+	})
+	return nil
+}
+
+func (g *Group) AddMember(userId string) error {
+	if g.member(userId) != nil {
+		return nil
+	}
+
+	member, err := NewGroupMember(&GroupMemberProps{UserId: userId})
+	if err != nil {
+		return err
+	}
+
+	g.members = append(g.members, member)
+	return nil
+}
+
+func (g *Group) RemoveMember(userId string) error {
+	if g.member(userId) == nil {
+		return ddgo.NewNotFoundError(fmt.Sprintf("user %s is not a member of group %s", userId, g.Uuid()))
+	}
+
+	g.members = slices.DeleteFunc(g.members, func(member *GroupMember) bool {
+		return member.UserId() == userId
+	})
+	return nil
+}
+
+// SetMainGroupFor flags this group as the user's main group. A user has a
+// single main group, so the repository clears the flag on every other group.
+func (g *Group) SetMainGroupFor(userId string) error {
+	member := g.member(userId)
+	if member == nil {
+		return ddgo.NewNotFoundError(fmt.Sprintf("user %s is not a member of group %s", userId, g.Uuid()))
+	}
+
+	member.main = true
+	return nil
+}
+
+func (g *Group) hasPermission(permission *GroupPermission) bool {
+	return slices.ContainsFunc(g.permissions, permission.Equals)
+}
+
+func (g *Group) member(userId string) *GroupMember {
+	for _, member := range g.members {
+		if member.UserId() == userId {
+			return member
+		}
+	}
+	return nil
+}
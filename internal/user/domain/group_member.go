@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"encoding/json"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+type GroupMemberProps struct {
+	UserId string `domainValidator:"required"`
+	Main   bool
+}
+
+type GroupMember struct {
+	userId string
+	main   bool
+}
+
+func NewGroupMember(props *GroupMemberProps) (*GroupMember, error) {
+	err := validateGroupMember(props)
+	if err != nil {
+		return nil, err
+	}
+	return &GroupMember{
+		userId: props.UserId,
+		main:   props.Main,
+	}, nil
+}
+
+func validateGroupMember(props *GroupMemberProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating group member data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+func (m *GroupMember) UserId() string {
+	return m.userId
+}
+
+func (m *GroupMember) Main() bool {
+	return m.main
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/json"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+type GroupPermissionProps struct {
+	Resource string `domainValidator:"required"`
+	Action   string `domainValidator:"required"`
+}
+
+type GroupPermission struct {
+	resource string
+	action   string
+}
+
+func NewGroupPermission(props *GroupPermissionProps) (*GroupPermission, error) {
+	err := validateGroupPermission(props)
+	if err != nil {
+		return nil, err
+	}
+	return &GroupPermission{
+		resource: props.Resource,
+		action:   props.Action,
+	}, nil
+}
+
+func validateGroupPermission(props *GroupPermissionProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating group permission data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+func (p *GroupPermission) Resource() string {
+	return p.resource
+}
+
+func (p *GroupPermission) Action() string {
+	return p.action
+}
+
+func (p *GroupPermission) Equals(other *GroupPermission) bool {
+	return p.resource == other.resource && p.action == other.action
+}
@@ -0,0 +1,167 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	domain "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+func newTestGroup(t *testing.T) *domain.Group {
+	t.Helper()
+	group, err := domain.NewGroup(&domain.GroupProps{
+		UuId: "0b7c9a4e-2f1d-4f7e-9a51-7c4f0d8e1a22",
+		Name: "admins",
+	})
+	if err != nil {
+		t.Fatalf("Should create group, got: %v", err)
+	}
+	return group
+}
+
+func TestNewGroup(t *testing.T) {
+	t.Run("Should success when create group with valid props", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		if group.Name() != "admins" {
+			t.Errorf("Should return name admins, got: %s", group.Name())
+		}
+	})
+
+	t.Run("Should fail when create group without name", func(t *testing.T) {
+		t.Parallel()
+		group, err := domain.NewGroup(&domain.GroupProps{UuId: "0b7c9a4e-2f1d-4f7e-9a51-7c4f0d8e1a22"})
+		if group != nil {
+			t.Error("Should return an error, got group")
+		}
+
+		if err == nil || err.Error() != `{"Name":{"IsValid":false,"FailedValidators":["required"]}}` {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+	})
+}
+
+func TestGroupRename(t *testing.T) {
+	t.Run("Should rename the group", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		if err := group.Rename("operators"); err != nil {
+			t.Errorf("Should rename group, got: %v", err)
+		}
+
+		if group.Name() != "operators" {
+			t.Errorf("Should return name operators, got: %s", group.Name())
+		}
+	})
+
+	t.Run("Should fail when rename to an empty name", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		err := group.Rename("")
+		if err == nil || err.Error() != `{"Name":{"IsValid":false,"FailedValidators":["required"]}}` {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+
+		if group.Name() != "admins" {
+			t.Errorf("Should keep name admins, got: %s", group.Name())
+		}
+	})
+}
+
+func TestGroupPermissions(t *testing.T) {
+	t.Run("Should keep a single entry when granting the same permission twice", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		props := &domain.GroupPermissionProps{Resource: "users", Action: "read"}
+		_ = group.GrantPermission(props)
+		_ = group.GrantPermission(props)
+
+		if len(group.Permissions()) != 1 {
+			t.Errorf("Should have 1 permission, got: %d", len(group.Permissions()))
+		}
+	})
+
+	t.Run("Should remove a revoked permission", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		_ = group.GrantPermission(&domain.GroupPermissionProps{Resource: "users", Action: "read"})
+		_ = group.GrantPermission(&domain.GroupPermissionProps{Resource: "users", Action: "create"})
+
+		err := group.RevokePermission(&domain.GroupPermissionProps{Resource: "users", Action: "read"})
+		if err != nil {
+			t.Errorf("Should revoke permission, got: %v", err)
+		}
+
+		if len(group.Permissions()) != 1 || group.Permissions()[0].Action() != "create" {
+			t.Errorf("Should keep only users:create, got: %v", group.Permissions())
+		}
+	})
+
+	t.Run("Should fail when granting a permission without action", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		err := group.GrantPermission(&domain.GroupPermissionProps{Resource: "users"})
+		if err == nil || err.Error() != `{"Action":{"IsValid":false,"FailedValidators":["required"]}}` {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+	})
+}
+
+func TestGroupMembers(t *testing.T) {
+	userId := "6f1d2c3b-4a5e-4f60-8a7b-9c0d1e2f3a4b"
+
+	t.Run("Should keep a single entry when adding the same user twice", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		_ = group.AddMember(userId)
+		_ = group.AddMember(userId)
+
+		if len(group.MemberIds()) != 1 || group.MemberIds()[0] != userId {
+			t.Errorf("Should have a single member, got: %v", group.MemberIds())
+		}
+	})
+
+	t.Run("Should remove a member", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		_ = group.AddMember(userId)
+		if err := group.RemoveMember(userId); err != nil {
+			t.Errorf("Should remove member, got: %v", err)
+		}
+
+		if len(group.Members()) != 0 {
+			t.Errorf("Should have no members, got: %d", len(group.Members()))
+		}
+	})
+
+	t.Run("Should return not found when removing a user that is not a member", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		err := group.RemoveMember(userId)
+		if _, ok := err.(*ddgo.NotFoundError); !ok {
+			t.Errorf("Should return NotFoundError, got: %T", err)
+		}
+	})
+
+	t.Run("Should flag the group as the member's main group", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		_ = group.AddMember(userId)
+		if err := group.SetMainGroupFor(userId); err != nil {
+			t.Errorf("Should set main group, got: %v", err)
+		}
+
+		if !group.Members()[0].Main() {
+			t.Error("Should flag member as main")
+		}
+	})
+
+	t.Run("Should return not found when setting main group for a non member", func(t *testing.T) {
+		t.Parallel()
+		group := newTestGroup(t)
+		err := group.SetMainGroupFor(userId)
+		if _, ok := err.(*ddgo.NotFoundError); !ok {
+			t.Errorf("Should return NotFoundError, got: %T", err)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"encoding/json"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+// PasswordVerifier compares a plaintext candidate against a stored hash.
+// It is implemented by the infrastructure hasher so that the domain never
+// depends on a concrete algorithm.
+type PasswordVerifier interface {
+	Verify(hash string, plain string) bool
+}
+
+type PasswordProps struct {
+	Hash string `domainValidator:"required"`
+}
+
+// Password only ever holds the hash of a secret; plaintext is checked against
+// PasswordPolicy and hashed before it reaches the domain.
+type Password struct {
+	hash string
+}
+
+func NewPassword(props *PasswordProps) (*Password, error) {
+	err := validatePassword(props)
+	if err != nil {
+		return nil, err
+	}
+	return &Password{
+		hash: props.Hash,
+	}, nil
+}
+
+func validatePassword(props *PasswordProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating password data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+func (p *Password) Hash() string {
+	return p.hash
+}
+
+func (p *Password) Matches(plain string, verifier PasswordVerifier) bool {
+	if plain == "" {
+		return false
+	}
+	return verifier.Verify(p.hash, plain)
+}
+
+type PasswordPolicy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// DefaultPasswordPolicy caps the length at 72 bytes because bcrypt silently
+// ignores anything past that.
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:     8,
+		MaxLength:     72,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+}
+
+func (p *PasswordPolicy) Validate(plain string) error {
+	failed := p.failedRules(plain)
+	if len(failed) == 0 {
+		return nil
+	}
+	return newFieldError("Password", failed...)
+}
+
+func (p *PasswordPolicy) failedRules(plain string) []string {
+	failed := make([]string, 0, 6)
+	if utf8.RuneCountInString(plain) < p.MinLength {
+		failed = append(failed, "minLength")
+	}
+	if p.MaxLength > 0 && len(plain) > p.MaxLength {
+		failed = append(failed, "maxLength")
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range plain {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		failed = append(failed, "upper")
+	}
+	if p.RequireLower && !hasLower {
+		failed = append(failed, "lower")
+	}
+	if p.RequireDigit && !hasDigit {
+		failed = append(failed, "digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		failed = append(failed, "symbol")
+	}
+	return failed
+}
+
+// NewBreachedPasswordError reports a password found in a known breach list
+// using the same field-level shape as the domain validator.
+func NewBreachedPasswordError() error {
+	return newFieldError("Password", "breached")
+}
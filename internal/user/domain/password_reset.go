@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+const DefaultPasswordResetTTL = time.Hour
+
+type PasswordResetProps struct {
+	Hash      string `domainValidator:"required"`
+	ExpiresAt time.Time
+}
+
+// PasswordReset is the pending, single-use permission to set a new password
+// without the current one. Only the hash of the token is kept.
+type PasswordReset struct {
+	hash      string
+	expiresAt time.Time
+}
+
+func NewPasswordReset(props *PasswordResetProps) (*PasswordReset, error) {
+	err := validatePasswordReset(props)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordReset{
+		hash:      props.Hash,
+		expiresAt: props.ExpiresAt,
+	}, nil
+}
+
+func validatePasswordReset(props *PasswordResetProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating password reset data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+// NewInvalidPasswordResetError reports a reset token that matches no pending
+// reset, also used when no user holds it.
+func NewInvalidPasswordResetError() error {
+	return newFieldError("Token", "invalid")
+}
+
+func HashPasswordResetToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *PasswordReset) Hash() string {
+	return r.hash
+}
+
+func (r *PasswordReset) ExpiresAt() time.Time {
+	return r.expiresAt
+}
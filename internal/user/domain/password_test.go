@@ -0,0 +1,102 @@
+package domain_test
+
+import (
+	"testing"
+
+	domain "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+func TestNewPassword(t *testing.T) {
+	t.Run("Should success when create password with a hash", func(t *testing.T) {
+		t.Parallel()
+		password, err := domain.NewPassword(&domain.PasswordProps{Hash: "$2a$12$hash"})
+		if err != nil {
+			t.Errorf("Should return a password, got: %v", err)
+		}
+
+		if password == nil {
+			t.Error("Should return a password, got nil")
+		}
+	})
+
+	t.Run("Should fail when create password with empty hash", func(t *testing.T) {
+		t.Parallel()
+		password, err := domain.NewPassword(&domain.PasswordProps{Hash: ""})
+		if err == nil {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+
+		if password != nil {
+			t.Error("Should return an error, got password")
+		}
+
+		if err.Error() != `{"Hash":{"IsValid":false,"FailedValidators":["required"]}}` {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+	})
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := domain.DefaultPasswordPolicy()
+	var cases = []struct {
+		description string
+		plain       string
+		expected    string
+	}{
+		{
+			description: "Should accept a password matching every rule",
+			plain:       "S3cr3t!pass",
+			expected:    "",
+		},
+		{
+			description: "Should reject a short password",
+			plain:       "S3c!r",
+			expected:    `{"Password":{"IsValid":false,"FailedValidators":["minLength"]}}`,
+		},
+		{
+			description: "Should reject a password longer than 72 bytes",
+			plain:       "S3cr3t!pass" + string(make([]byte, 70)),
+			expected:    `{"Password":{"IsValid":false,"FailedValidators":["maxLength"]}}`,
+		},
+		{
+			description: "Should report every missing character class",
+			plain:       "abcdefghij",
+			expected:    `{"Password":{"IsValid":false,"FailedValidators":["upper","digit","symbol"]}}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			err := policy.Validate(c.plain)
+			if c.expected == "" {
+				if err != nil {
+					t.Errorf("Should return nil, got: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != c.expected {
+				t.Errorf("Should return %s, got: %v", c.expected, err)
+			}
+		})
+	}
+}
+
+func TestPasswordMatches(t *testing.T) {
+	password, _ := domain.NewPassword(&domain.PasswordProps{Hash: "hashed:S3cr3t!pass"})
+
+	t.Run("Should match the original plaintext", func(t *testing.T) {
+		t.Parallel()
+		if !password.Matches("S3cr3t!pass", fakeVerifier{}) {
+			t.Error("Should match, got false")
+		}
+	})
+
+	t.Run("Should not match a different plaintext", func(t *testing.T) {
+		t.Parallel()
+		if password.Matches("other", fakeVerifier{}) {
+			t.Error("Should not match, got true")
+		}
+	})
+}
@@ -2,16 +2,19 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/jeffersonbrasilino/ddgo"
 )
 
 type PersonProps struct {
-	UuId      string `domainValidator:"required"`
-	Name      string `domainValidator:"required"`
-	BirthDate string `domainValidator:"required"`
+	UuId      string     `domainValidator:"required"`
+	Name      string     `domainValidator:"required"`
+	BirthDate *BirthDate `domainValidator:"required"`
 	Contacts  []*Contact
 	Document  *Document
+	Version   int
 }
 
 type Person struct {
@@ -19,7 +22,8 @@ type Person struct {
 	contacts  []*Contact
 	document  *Document
 	name      string
-	birthDate string
+	birthDate *BirthDate
+	version   int
 }
 
 func NewPerson(props *PersonProps) (*Person, error) {
@@ -27,15 +31,44 @@ func NewPerson(props *PersonProps) (*Person, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	contacts, err := withSingleMain(props.Contacts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Person{
 		name:      props.Name,
 		birthDate: props.BirthDate,
-		contacts:  props.Contacts,
+		contacts:  contacts,
 		document:  props.Document,
+		version:   props.Version,
 		Entity:    ddgo.NewEntity(props.UuId),
 	}, nil
 }
 
+// withSingleMain copies contacts making sure exactly one is main, contacts
+// stored before the main flag existed fall back to the first one.
+func withSingleMain(contacts []*Contact) ([]*Contact, error) {
+	copied := make([]*Contact, 0, len(contacts))
+	mains := 0
+	for _, contact := range contacts {
+		if contact.main {
+			mains++
+		}
+		copied = append(copied, contact)
+	}
+
+	if mains > 1 {
+		return nil, newFieldError("Contacts", "singleMain")
+	}
+
+	if mains == 0 && len(copied) > 0 {
+		copied[0] = copied[0].withMain(true)
+	}
+	return copied, nil
+}
+
 func validatePerson(props *PersonProps) error {
 	validator := ddgo.ValidatorInstance()
 	validationErrors, faliedValidation := validator.Validate(props)
@@ -66,6 +99,146 @@ func (p *Person) Contacts() []*Contact {
 	return p.contacts
 }
 
-func (p *Person) BirthDate() string {
+// Version is the optimistic concurrency token of the stored person.
+func (p *Person) Version() int {
+	return p.version
+}
+
+// Contact returns nil when the person has no contact with the id.
+func (p *Person) Contact(contactId string) *Contact {
+	for _, contact := range p.contacts {
+		if contact.Uuid() == contactId {
+			return contact
+		}
+	}
+	return nil
+}
+
+func (p *Person) MainContact() *Contact {
+	for _, contact := range p.contacts {
+		if contact.main {
+			return contact
+		}
+	}
+	return nil
+}
+
+// AddContact keeps a single main contact: the first contact becomes main and
+// a new main contact takes the flag from the previous one.
+func (p *Person) AddContact(contact *Contact) error {
+	for _, current := range p.contacts {
+		if current.contactType == contact.contactType && current.description == contact.description {
+			return ddgo.NewAlreadyExistsError(fmt.Sprintf("%s contact %s already registered", contact.contactType, contact.description))
+		}
+	}
+
+	if len(p.contacts) == 0 {
+		contact = contact.withMain(true)
+	}
+
+	if contact.main {
+		p.unsetMainContact()
+	}
+
+	p.contacts = append(p.contacts, contact)
+	return nil
+}
+
+// RemoveContact hands the main flag to the first remaining contact when the
+// main one is removed.
+func (p *Person) RemoveContact(contactId string) error {
+	for i, contact := range p.contacts {
+		if contact.Uuid() != contactId {
+			continue
+		}
+
+		p.contacts = append(p.contacts[:i:i], p.contacts[i+1:]...)
+		if contact.main && len(p.contacts) > 0 {
+			p.contacts[0] = p.contacts[0].withMain(true)
+		}
+		return nil
+	}
+	return ddgo.NewNotFoundError(fmt.Sprintf("contact %s not found", contactId))
+}
+
+func (p *Person) SetMainContact(contactId string) error {
+	for i, contact := range p.contacts {
+		if contact.Uuid() == contactId {
+			p.unsetMainContact()
+			p.contacts[i] = contact.withMain(true)
+			return nil
+		}
+	}
+	return ddgo.NewNotFoundError(fmt.Sprintf("contact %s not found", contactId))
+}
+
+// ReplaceContacts swaps the whole contact list. A contact equal to a current
+// one (same type and description) keeps the current identity.
+func (p *Person) ReplaceContacts(contacts []*Contact) error {
+	replaced, err := withSingleMain(contacts)
+	if err != nil {
+		return err
+	}
+
+	for i, contact := range replaced {
+		for _, other := range replaced[:i] {
+			if other.contactType == contact.contactType && other.description == contact.description {
+				return newFieldError("Contacts", "unique")
+			}
+		}
+
+		for _, current := range p.contacts {
+			if current.contactType == contact.contactType && current.description == contact.description {
+				replaced[i] = contact.withIdentityOf(current)
+			}
+		}
+	}
+
+	p.contacts = replaced
+	return nil
+}
+
+func (p *Person) verifyContact(contactId string, now time.Time) error {
+	for i, contact := range p.contacts {
+		if contact.Uuid() == contactId {
+			p.contacts[i] = contact.withVerifiedAt(now)
+			return nil
+		}
+	}
+
+	return ddgo.NewNotFoundError(fmt.Sprintf("contact %s not found", contactId))
+}
+
+func (p *Person) unsetMainContact() {
+	for i, contact := range p.contacts {
+		if contact.main {
+			p.contacts[i] = contact.withMain(false)
+		}
+	}
+}
+
+func (p *Person) BirthDate() *BirthDate {
 	return p.birthDate
 }
+
+// ChangeDetails replaces the personal details kept by the person. Nothing
+// changes when the new details are invalid.
+func (p *Person) ChangeDetails(name string, birthDate string) error {
+	newBirthDate, err := NewBirthDate(&BirthDateProps{Value: birthDate})
+	if err != nil {
+		return err
+	}
+
+	err = validatePerson(&PersonProps{
+		UuId:      p.Uuid(),
+		Name:      name,
+		BirthDate: newBirthDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.name = name
+	p.birthDate = newBirthDate
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/jeffersonbrasilino/ddgo"
 	domain "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
 )
 
@@ -12,16 +13,16 @@ func TestNewPerson(t *testing.T) {
 		t.Parallel()
 		ctt, _ := domain.NewContact(&domain.ContactProps{
 			UuId:        "1",
-			Description: "1",
-			ContactType: "1",
+			Description: "john@example.com",
+			ContactType: "email",
 		})
 		dcm, _ := domain.NewDocument(&domain.DocumentProps{
-			Value: "1",
+			Value: "52998224725",
 		})
 		props := &domain.PersonProps{
 			UuId:      "1",
 			Name:      "John Doe",
-			BirthDate: "2000-01-01",
+			BirthDate: newTestBirthDate("2000-01-01"),
 			Contacts: []*domain.Contact{
 				ctt,
 			},
@@ -43,7 +44,7 @@ func TestNewPerson(t *testing.T) {
 		props := &domain.PersonProps{
 			UuId:      "",
 			Name:      "",
-			BirthDate: "",
+			BirthDate: nil,
 			Contacts:  make([]*domain.Contact, 0),
 			Document:  nil,
 		}
@@ -66,16 +67,16 @@ func TestPersonGetters(t *testing.T) {
 		t.Parallel()
 		ctt, _ := domain.NewContact(&domain.ContactProps{
 			UuId:        "1",
-			Description: "1",
-			ContactType: "1",
+			Description: "john@example.com",
+			ContactType: "email",
 		})
 		dcm, _ := domain.NewDocument(&domain.DocumentProps{
-			Value: "1",
+			Value: "52998224725",
 		})
 		props := &domain.PersonProps{
 			UuId:      "1",
 			Name:      "John Doe",
-			BirthDate: "2000-01-01",
+			BirthDate: newTestBirthDate("2000-01-01"),
 			Contacts: []*domain.Contact{
 				ctt,
 			},
@@ -88,17 +89,198 @@ func TestPersonGetters(t *testing.T) {
 			t.Errorf("Should return the correct name, got: %v", person.Name())
 		}
 
-		if person.BirthDate() != "2000-01-01" {
-			t.Errorf("Should return the correct birth date, got: %v", person.BirthDate())
+		if person.BirthDate().Value() != "2000-01-01" {
+			t.Errorf("Should return the correct birth date, got: %v", person.BirthDate().Value())
 		}
 
-		if person.Contacts()[0].Description() != "1" {
+		if person.Contacts()[0].Description() != "john@example.com" {
 			t.Errorf("Should return the correct contact value, got: %v", person.Contacts()[0].Description())
 		}
 
-		if person.Document().Value() != "1" {
+		if person.Document().Value() != "52998224725" {
 			t.Errorf("Should return the correct document value, got: %v", person.Document().Value())
 		}
 	})
 }
 
+func TestPersonChangeDetails(t *testing.T) {
+	newPerson := func() *domain.Person {
+		person, _ := domain.NewPerson(&domain.PersonProps{
+			UuId:      "1",
+			Name:      "John Doe",
+			BirthDate: newTestBirthDate("2000-01-01"),
+		})
+		return person
+	}
+
+	t.Run("Should replace name and birth date", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson()
+		if err := person.ChangeDetails("John Smith", "1999-12-31"); err != nil {
+			t.Errorf("Should change details, got: %v", err)
+		}
+
+		if person.Name() != "John Smith" || person.BirthDate().Value() != "1999-12-31" {
+			t.Errorf("Should return the new details, got: %s %s", person.Name(), person.BirthDate().Value())
+		}
+	})
+
+	t.Run("Should keep current details when the new ones are invalid", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson()
+		err := person.ChangeDetails("", "1999-12-31")
+		if err == nil || err.Error() != `{"Name":{"IsValid":false,"FailedValidators":["required"]}}` {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+
+		if person.Name() != "John Doe" || person.BirthDate().Value() != "2000-01-01" {
+			t.Errorf("Should keep the current details, got: %s %s", person.Name(), person.BirthDate().Value())
+		}
+	})
+}
+
+func newTestContact(t *testing.T, uuid string, contactType domain.ContactType, description string, main bool) *domain.Contact {
+	t.Helper()
+	contact, err := domain.NewContact(&domain.ContactProps{
+		UuId:        uuid,
+		Description: description,
+		ContactType: string(contactType),
+		Main:        main,
+	})
+	if err != nil {
+		t.Fatalf("Should create contact, got: %v", err)
+	}
+	return contact
+}
+
+func TestPersonContacts(t *testing.T) {
+	newPerson := func(t *testing.T, contacts ...*domain.Contact) *domain.Person {
+		t.Helper()
+		person, err := domain.NewPerson(&domain.PersonProps{
+			UuId:      "1",
+			Name:      "John Doe",
+			BirthDate: newTestBirthDate("2000-01-01"),
+			Contacts:  contacts,
+		})
+		if err != nil {
+			t.Fatalf("Should create person, got: %v", err)
+		}
+		return person
+	}
+
+	t.Run("Should fail when more than one contact is main", func(t *testing.T) {
+		t.Parallel()
+		person, err := domain.NewPerson(&domain.PersonProps{
+			UuId:      "1",
+			Name:      "John Doe",
+			BirthDate: newTestBirthDate("2000-01-01"),
+			Contacts: []*domain.Contact{
+				newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", true),
+				newTestContact(t, "c2", domain.ContactTypeMobile, "+5511987654321", true),
+			},
+		})
+		if person != nil {
+			t.Error("Should return an error, got person")
+		}
+
+		if err == nil || err.Error() != `{"Contacts":{"IsValid":false,"FailedValidators":["singleMain"]}}` {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+	})
+
+	t.Run("Should make the first contact main when none is", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson(t,
+			newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", false),
+			newTestContact(t, "c2", domain.ContactTypeMobile, "+5511987654321", false),
+		)
+
+		if person.MainContact() == nil || person.MainContact().Uuid() != "c1" {
+			t.Errorf("Should make c1 main, got: %v", person.MainContact())
+		}
+	})
+
+	t.Run("Should make the first added contact main", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson(t)
+		if err := person.AddContact(newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", false)); err != nil {
+			t.Fatalf("Should add contact, got: %v", err)
+		}
+
+		if person.MainContact() == nil || person.MainContact().Uuid() != "c1" {
+			t.Errorf("Should make c1 main, got: %v", person.MainContact())
+		}
+	})
+
+	t.Run("Should move the main flag to a new main contact", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson(t, newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", true))
+		_ = person.AddContact(newTestContact(t, "c2", domain.ContactTypeMobile, "+5511987654321", true))
+
+		mains := 0
+		for _, contact := range person.Contacts() {
+			if contact.Main() {
+				mains++
+			}
+		}
+
+		if mains != 1 || person.MainContact().Uuid() != "c2" {
+			t.Errorf("Should keep only c2 as main, got: %d main contacts", mains)
+		}
+	})
+
+	t.Run("Should fail when adding the same contact twice", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson(t, newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", true))
+		err := person.AddContact(newTestContact(t, "c2", domain.ContactTypeEmail, "john@EXAMPLE.com", false))
+		if _, ok := err.(*ddgo.AlreadyExistsError); !ok {
+			t.Errorf("Should return AlreadyExistsError, got: %T", err)
+		}
+	})
+
+	t.Run("Should promote another contact when the main one is removed", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson(t,
+			newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", true),
+			newTestContact(t, "c2", domain.ContactTypeMobile, "+5511987654321", false),
+		)
+
+		if err := person.RemoveContact("c1"); err != nil {
+			t.Fatalf("Should remove contact, got: %v", err)
+		}
+
+		if len(person.Contacts()) != 1 || person.MainContact().Uuid() != "c2" {
+			t.Errorf("Should make c2 main, got: %v", person.MainContact())
+		}
+	})
+
+	t.Run("Should set the main contact", func(t *testing.T) {
+		t.Parallel()
+		first := newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", true)
+		person := newPerson(t, first, newTestContact(t, "c2", domain.ContactTypeMobile, "+5511987654321", false))
+
+		if err := person.SetMainContact("c2"); err != nil {
+			t.Fatalf("Should set main contact, got: %v", err)
+		}
+
+		if person.MainContact().Uuid() != "c2" || person.Contacts()[0].Main() {
+			t.Error("Should keep only c2 as main")
+		}
+
+		if !first.Main() {
+			t.Error("Should not change the contact given by the caller")
+		}
+	})
+
+	t.Run("Should return not found for unknown contacts", func(t *testing.T) {
+		t.Parallel()
+		person := newPerson(t, newTestContact(t, "c1", domain.ContactTypeEmail, "john@example.com", true))
+		if _, ok := person.RemoveContact("c9").(*ddgo.NotFoundError); !ok {
+			t.Error("Should return NotFoundError when removing")
+		}
+
+		if _, ok := person.SetMainContact("c9").(*ddgo.NotFoundError); !ok {
+			t.Error("Should return NotFoundError when setting main")
+		}
+	})
+}
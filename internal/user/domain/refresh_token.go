@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+type RefreshTokenProps struct {
+	UuId      string `domainValidator:"required"`
+	UserId    string `domainValidator:"required"`
+	DeviceId  string `domainValidator:"required"`
+	FamilyId  string `domainValidator:"required"`
+	TokenHash string `domainValidator:"required"`
+	ExpiresAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+type IssueRefreshTokenProps struct {
+	UuId      string
+	FamilyId  string
+	UserId    string
+	DeviceId  string
+	ExpiresAt time.Time
+}
+
+// RefreshToken is an opaque, single-use credential. Every login starts a new
+// family and every refresh rotates the token inside that family, so presenting
+// an already rotated token means it leaked and the whole family must die.
+type RefreshToken struct {
+	*ddgo.Entity
+	userId    string
+	deviceId  string
+	familyId  string
+	tokenHash string
+	expiresAt time.Time
+	rotatedAt *time.Time
+	revokedAt *time.Time
+}
+
+func NewRefreshToken(props *RefreshTokenProps) (*RefreshToken, error) {
+	err := validateRefreshToken(props)
+	if err != nil {
+		return nil, err
+	}
+	return &RefreshToken{
+		Entity:    ddgo.NewEntity(props.UuId),
+		userId:    props.UserId,
+		deviceId:  props.DeviceId,
+		familyId:  props.FamilyId,
+		tokenHash: props.TokenHash,
+		expiresAt: props.ExpiresAt,
+		rotatedAt: props.RotatedAt,
+		revokedAt: props.RevokedAt,
+	}, nil
+}
+
+// IssueRefreshToken returns the new token together with its plaintext value,
+// which is handed to the client once and never stored.
+func IssueRefreshToken(props *IssueRefreshTokenProps) (*RefreshToken, string, error) {
+	plain, err := newOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := NewRefreshToken(&RefreshTokenProps{
+		UuId:      props.UuId,
+		UserId:    props.UserId,
+		DeviceId:  props.DeviceId,
+		FamilyId:  props.FamilyId,
+		TokenHash: HashRefreshToken(plain),
+		ExpiresAt: props.ExpiresAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return token, plain, nil
+}
+
+func validateRefreshToken(props *RefreshTokenProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating refresh token data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+func HashRefreshToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func newOpaqueToken() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", ddgo.NewInternalError("Error when generating refresh token")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func (t *RefreshToken) Rotate(uuId string, expiresAt time.Time, now time.Time) (*RefreshToken, string, error) {
+	next, plain, err := IssueRefreshToken(&IssueRefreshTokenProps{
+		UuId:      uuId,
+		FamilyId:  t.familyId,
+		UserId:    t.userId,
+		DeviceId:  t.deviceId,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	t.rotatedAt = &now
+	return next, plain, nil
+}
+
+func (t *RefreshToken) IsReused() bool {
+	return t.rotatedAt != nil
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return t.revokedAt != nil
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
+
+func (t *RefreshToken) UserId() string {
+	return t.userId
+}
+
+func (t *RefreshToken) DeviceId() string {
+	return t.deviceId
+}
+
+func (t *RefreshToken) FamilyId() string {
+	return t.familyId
+}
+
+func (t *RefreshToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *RefreshToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *RefreshToken) RotatedAt() *time.Time {
+	return t.rotatedAt
+}
+
+func (t *RefreshToken) RevokedAt() *time.Time {
+	return t.revokedAt
+}
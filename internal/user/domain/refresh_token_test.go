@@ -0,0 +1,109 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+func issueRefreshToken(t *testing.T, expiresAt time.Time) (*domain.RefreshToken, string) {
+	token, plain, err := domain.IssueRefreshToken(&domain.IssueRefreshTokenProps{
+		UuId:      "token-uuid-1",
+		FamilyId:  "family-uuid-1",
+		UserId:    "user-uuid-1",
+		DeviceId:  "device-1",
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("Should issue a refresh token, got: %v", err)
+	}
+	return token, plain
+}
+
+func TestIssueRefreshToken(t *testing.T) {
+	t.Run("Should store only the hash of the plaintext token", func(t *testing.T) {
+		t.Parallel()
+		token, plain := issueRefreshToken(t, time.Now().Add(time.Hour))
+
+		if plain == "" || token.TokenHash() == plain {
+			t.Errorf("Should keep the plaintext out of the entity, got hash: %v", token.TokenHash())
+		}
+
+		if token.TokenHash() != domain.HashRefreshToken(plain) {
+			t.Error("Should store the hash of the returned plaintext")
+		}
+	})
+
+	t.Run("Should fail when required props are missing", func(t *testing.T) {
+		t.Parallel()
+		token, _, err := domain.IssueRefreshToken(&domain.IssueRefreshTokenProps{})
+		if err == nil {
+			t.Errorf("Should return an error, got: %v", err)
+		}
+
+		if token != nil {
+			t.Error("Should return nil token, got token")
+		}
+	})
+}
+
+func TestRefreshTokenRotate(t *testing.T) {
+	t.Run("Should keep the family and mark the current token as reused", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		current, plain := issueRefreshToken(t, now.Add(time.Hour))
+
+		next, nextPlain, err := current.Rotate("token-uuid-2", now.Add(time.Hour), now)
+		if err != nil {
+			t.Fatalf("Should rotate, got: %v", err)
+		}
+
+		if next.FamilyId() != current.FamilyId() || next.UserId() != current.UserId() || next.DeviceId() != current.DeviceId() {
+			t.Error("Should keep family, user and device on rotation")
+		}
+
+		if nextPlain == plain {
+			t.Error("Should generate a new plaintext token")
+		}
+
+		if !current.IsReused() || next.IsReused() {
+			t.Error("Should flag only the rotated token as reused")
+		}
+	})
+}
+
+func TestRefreshTokenState(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+	revoked, _ := domain.NewRefreshToken(&domain.RefreshTokenProps{
+		UuId:      "token-uuid-1",
+		UserId:    "user-uuid-1",
+		DeviceId:  "device-1",
+		FamilyId:  "family-uuid-1",
+		TokenHash: "hash",
+		ExpiresAt: now.Add(time.Hour),
+		RevokedAt: &revokedAt,
+	})
+	expired, _ := issueRefreshToken(t, now.Add(-time.Second))
+
+	var cases = []struct {
+		description string
+		getFunc     func() any
+		expected    any
+	}{
+		{"Should report a revoked token", func() any { return revoked.IsRevoked() }, true},
+		{"Should not report a revoked token as expired", func() any { return revoked.IsExpired(now) }, false},
+		{"Should report an expired token", func() any { return expired.IsExpired(now) }, true},
+		{"Should not report an expired token as revoked", func() any { return expired.IsRevoked() }, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			if got := c.getFunc(); got != c.expected {
+				t.Errorf("Should return %v, got: %v", c.expected, got)
+			}
+		})
+	}
+}
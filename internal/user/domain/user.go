@@ -2,23 +2,51 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jeffersonbrasilino/ddgo"
 	domain "github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/events"
 )
 
 type UserProps struct {
-	UuId     string  `domainValidator:"required"`
-	Username string  `domainValidator:"required,gte=1"`
-	Password string  `domainValidator:"required"`
-	Person   *Person `domainValidator:"required"`
+	UuId     string    `domainValidator:"required"`
+	Username string    `domainValidator:"required,gte=1"`
+	Password *Password `domainValidator:"required"`
+	Person   *Person   `domainValidator:"required"`
+	Version  int
+	// Status defaults to active.
+	Status        UserStatus
+	DeletedAt     *time.Time
+	Verification  *VerificationCode
+	PasswordReset *PasswordReset
+	Devices       []*Device
 }
 
 type User struct {
 	*domain.AggregateRoot
-	username string
-	password string
-	person   *Person
+	username      string
+	password      *Password
+	person        *Person
+	version       int
+	status        UserStatus
+	deletedAt     *time.Time
+	verification  *VerificationCode
+	passwordReset *PasswordReset
+	devices       []*Device
+	// changedDevices holds the ids of the devices changed since the user was
+	// loaded, the only ones SaveDevices writes
+	changedDevices []string
+}
+
+// ProfileChanges holds the profile fields to replace, nil fields are kept.
+// Contacts replace the whole list when not nil, an empty slice removes them.
+type ProfileChanges struct {
+	Name      *string
+	BirthDate *string
+	Contacts  []*ContactProps
 }
 
 func NewUser(props *UserProps) (*User, error) {
@@ -32,12 +60,46 @@ func NewUser(props *UserProps) (*User, error) {
 		username:      props.Username,
 		password:      props.Password,
 		person:        props.Person,
+		version:       props.Version,
+		status:        props.Status,
+		deletedAt:     props.DeletedAt,
+		verification:  props.Verification,
+		passwordReset: props.PasswordReset,
+		devices:       props.Devices,
+	}
+
+	if entity.status == "" {
+		entity.status = UserStatusActive
 	}
 
 	return entity, nil
 }
 
+// RegisterUser creates a brand-new user and records UserCreated. Use NewUser
+// to rehydrate users that already exist.
+func RegisterUser(props *UserProps) (*User, error) {
+	user, err := NewUser(props)
+	if err != nil {
+		return nil, err
+	}
+
+	user.AddDomainEvent(events.NewUserCreated(user.Uuid(), user.Username(), user.Person().Uuid()))
+	return user, nil
+}
+
 func validate(props *UserProps) error {
+	if props.Password == nil {
+		return newFieldError("Password", "required")
+	}
+
+	if props.Person == nil {
+		return newFieldError("Person", "required")
+	}
+
+	if props.Status != "" && !props.Status.valid() {
+		return newFieldError("Status", "oneof")
+	}
+
 	validator := ddgo.ValidatorInstance()
 	validationErrors, faliedValidation := validator.Validate(props)
 	if faliedValidation != nil {
@@ -55,7 +117,7 @@ func validate(props *UserProps) error {
 	return nil
 }
 
-func (u *User) Password() string {
+func (u *User) Password() *Password {
 	return u.password
 }
 
@@ -63,10 +125,416 @@ func (u *User) Username() string {
 	return u.username
 }
 
-func (u *User) SetPassword(password string) {
+func (u *User) SetPassword(password *Password) {
 	u.password = password
 }
 
+// ChangePassword replaces the password when current matches it, drops any
+// pending reset and records PasswordChanged.
+func (u *User) ChangePassword(current string, verifier PasswordVerifier, next *Password) error {
+	if !u.password.Matches(current, verifier) {
+		return newFieldError("CurrentPassword", "password")
+	}
+
+	u.replacePassword(next, events.PasswordChangedByUser)
+	return nil
+}
+
+func (u *User) PasswordReset() *PasswordReset {
+	return u.passwordReset
+}
+
+// RequestPasswordReset replaces any pending reset with a new one and returns
+// its plain token, to be delivered and never kept.
+func (u *User) RequestPasswordReset(ttl time.Duration, now time.Time) (string, error) {
+	if u.Email() == nil {
+		return "", newFieldError("Contacts", "email")
+	}
+
+	plain, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	reset, err := NewPasswordReset(&PasswordResetProps{
+		Hash:      HashPasswordResetToken(plain),
+		ExpiresAt: now.Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	u.passwordReset = reset
+	return plain, nil
+}
+
+// ResetPassword uses the pending reset, which works once, to replace the
+// password and records PasswordChanged.
+func (u *User) ResetPassword(token string, next *Password, now time.Time) error {
+	if u.passwordReset == nil || u.passwordReset.hash != HashPasswordResetToken(token) {
+		return NewInvalidPasswordResetError()
+	}
+
+	if !now.Before(u.passwordReset.expiresAt) {
+		return newFieldError("Token", "expired")
+	}
+
+	u.replacePassword(next, events.PasswordChangedByReset)
+	return nil
+}
+
+func (u *User) replacePassword(next *Password, reason string) {
+	u.password = next
+	u.passwordReset = nil
+	u.AddDomainEvent(events.NewPasswordChanged(u.Uuid(), reason))
+}
+
+func (u *User) VerifyPassword(plain string, verifier PasswordVerifier) bool {
+	return u.password.Matches(plain, verifier)
+}
+
 func (u *User) Person() *Person {
 	return u.person
 }
+
+// Version is the optimistic concurrency token of the stored user, the
+// repository refuses to save over a newer one.
+func (u *User) Version() int {
+	return u.version
+}
+
+// UpdateProfile applies changes to the person and records UserProfileUpdated
+// with the fields that really changed. Nothing changes when any of them is
+// invalid.
+func (u *User) UpdateProfile(changes *ProfileChanges) error {
+	name := u.person.Name()
+	if changes.Name != nil {
+		name = *changes.Name
+	}
+
+	birthDate := u.person.BirthDate().Value()
+	if changes.BirthDate != nil {
+		birthDate = *changes.BirthDate
+	}
+
+	updated := *u.person
+	if err := updated.ChangeDetails(name, birthDate); err != nil {
+		return err
+	}
+
+	if changes.Contacts != nil {
+		contacts := make([]*Contact, 0, len(changes.Contacts))
+		for _, props := range changes.Contacts {
+			contact, err := NewContact(props)
+			if err != nil {
+				return err
+			}
+			contacts = append(contacts, contact)
+		}
+
+		if err := updated.ReplaceContacts(contacts); err != nil {
+			return err
+		}
+	}
+
+	changed := make([]string, 0, 3)
+	if updated.name != u.person.name {
+		changed = append(changed, "name")
+	}
+	if updated.birthDate.Value() != u.person.birthDate.Value() {
+		changed = append(changed, "birthDate")
+	}
+	if !sameContacts(updated.contacts, u.person.contacts) {
+		changed = append(changed, "contacts")
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	u.person = &updated
+	u.AddDomainEvent(events.NewUserProfileUpdated(u.Uuid(), u.person.Uuid(), changed))
+	return nil
+}
+
+// ChangeUsername records UsernameChanged, keeping the same username is a
+// no-op.
+func (u *User) ChangeUsername(username string) error {
+	if username == "" {
+		return newFieldError("Username", "required")
+	}
+
+	if username == u.username {
+		return nil
+	}
+
+	previous := u.username
+	u.username = username
+	u.AddDomainEvent(events.NewUsernameChanged(u.Uuid(), previous, username))
+	return nil
+}
+
+func (u *User) Status() UserStatus {
+	return u.status
+}
+
+// IsActive tells whether the user may sign in and be authorized.
+func (u *User) IsActive() bool {
+	return u.status == UserStatusActive
+}
+
+func (u *User) DeletedAt() *time.Time {
+	return u.deletedAt
+}
+
+// Deactivate suspends an active user and records UserDeactivated.
+func (u *User) Deactivate() error {
+	if err := u.changeStatus(UserStatusInactive); err != nil {
+		return err
+	}
+
+	u.AddDomainEvent(events.NewUserDeactivated(u.Uuid()))
+	return nil
+}
+
+// Reactivate lifts the suspension of an inactive user and records
+// UserReactivated.
+func (u *User) Reactivate() error {
+	if err := u.changeStatus(UserStatusActive); err != nil {
+		return err
+	}
+
+	u.AddDomainEvent(events.NewUserReactivated(u.Uuid()))
+	return nil
+}
+
+// Delete soft deletes the user and records UserDeleted. Its personal data is
+// kept until the retention period ends and it is anonymized.
+func (u *User) Delete(now time.Time) error {
+	if err := u.changeStatus(UserStatusDeleted); err != nil {
+		return err
+	}
+
+	u.deletedAt = &now
+	u.AddDomainEvent(events.NewUserDeleted(u.Uuid()))
+	return nil
+}
+
+func (u *User) changeStatus(next UserStatus) error {
+	if !u.status.canBecome(next) {
+		return ErrStatusTransition
+	}
+
+	u.status = next
+	return nil
+}
+
+// Verification is the pending email verification, nil when there is none.
+func (u *User) Verification() *VerificationCode {
+	return u.verification
+}
+
+// RequestVerification issues a code for the email contact, the main one when
+// it is an email, and records VerificationRequested. The plain code is
+// returned to be delivered and is never kept.
+func (u *User) RequestVerification(policy *VerificationPolicy, now time.Time) (string, error) {
+	contact := u.Email()
+	if contact == nil {
+		return "", newFieldError("Contacts", "email")
+	}
+
+	if contact.Verified() {
+		return "", ddgo.NewAlreadyExistsError(fmt.Sprintf("contact %s already verified", contact.Uuid()))
+	}
+
+	if u.verification != nil && now.Sub(u.verification.sentAt) < policy.ResendCooldown {
+		return "", newFieldError("Verification", "cooldown")
+	}
+
+	code, plain, err := issueVerificationCode(u.Uuid(), contact.Uuid(), policy, now)
+	if err != nil {
+		return "", err
+	}
+
+	if u.verification != nil && now.Sub(u.verification.sentAt) < policy.AttemptsWindow {
+		code.attempts = u.verification.attempts
+	}
+
+	u.verification = code
+	u.AddDomainEvent(events.NewVerificationRequested(u.Uuid(), contact.Uuid(), code.expiresAt))
+	return plain, nil
+}
+
+// VerifyEmail marks the contact the code was sent to as verified and records
+// EmailVerified. A wrong code counts as an attempt, after
+// policy.MaxAttempts a new code must be requested once policy.AttemptsWindow
+// has passed since the last one.
+func (u *User) VerifyEmail(plain string, policy *VerificationPolicy, now time.Time) error {
+	if u.verification == nil {
+		return newFieldError("Code", "pending")
+	}
+
+	if u.verification.attempts >= policy.MaxAttempts {
+		return newFieldError("Code", "attempts")
+	}
+
+	if !now.Before(u.verification.expiresAt) {
+		return newFieldError("Code", "expired")
+	}
+
+	if !u.verification.matches(u.Uuid(), plain) {
+		u.verification.attempts++
+		return newFieldError("Code", "code")
+	}
+
+	contactId := u.verification.contactId
+	if err := u.person.verifyContact(contactId, now); err != nil {
+		return err
+	}
+
+	u.verification = nil
+	u.AddDomainEvent(events.NewEmailVerified(u.Uuid(), contactId))
+	return nil
+}
+
+// Email is the contact used to reach the user: the main contact when it is
+// an email, otherwise the first email. Nil when the person has none.
+func (u *User) Email() *Contact {
+	if main := u.person.MainContact(); main != nil && main.contactType == ContactTypeEmail {
+		return main
+	}
+
+	for _, contact := range u.person.Contacts() {
+		if contact.contactType == ContactTypeEmail {
+			return contact
+		}
+	}
+	return nil
+}
+
+func sameContacts(a []*Contact, b []*Contact) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Uuid() != b[i].Uuid() || a[i].description != b[i].description ||
+			a[i].contactType != b[i].contactType || a[i].main != b[i].main {
+			return false
+		}
+	}
+	return true
+}
+
+// Devices lists every device the user logged in from, revoked ones included.
+func (u *User) Devices() []*Device {
+	return append([]*Device(nil), u.devices...)
+}
+
+// ActiveDevices lists the devices that may still hold a session.
+func (u *User) ActiveDevices() []*Device {
+	active := make([]*Device, 0, len(u.devices))
+	for _, device := range u.devices {
+		if device.IsActive() {
+			active = append(active, device)
+		}
+	}
+	return active
+}
+
+func (u *User) Device(deviceId string) *Device {
+	for _, device := range u.devices {
+		if device.DeviceId() == deviceId {
+			return device
+		}
+	}
+	return nil
+}
+
+// ChangedDevices lists the devices registered, seen or revoked since the user
+// was loaded.
+func (u *User) ChangedDevices() []*Device {
+	changed := make([]*Device, 0, len(u.changedDevices))
+	for _, deviceId := range u.changedDevices {
+		changed = append(changed, u.Device(deviceId))
+	}
+	return changed
+}
+
+// RegisterDevice records a login from the device, which also brings back a
+// revoked device. A device seen for the first time records DeviceRegistered.
+func (u *User) RegisterDevice(deviceId string, userAgent string, ip string, now time.Time) error {
+	if device := u.Device(deviceId); device != nil {
+		device.seen(userAgent, ip, now)
+		device.revokedAt = nil
+		u.deviceChanged(deviceId)
+		return nil
+	}
+
+	device, err := NewDevice(&DeviceProps{
+		DeviceId:     deviceId,
+		UserAgent:    userAgent,
+		LastIp:       ip,
+		LastSeenAt:   now,
+		RegisteredAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	u.devices = append(u.devices, device)
+	u.deviceChanged(deviceId)
+	u.AddDomainEvent(events.NewDeviceRegistered(u.Uuid(), deviceId))
+	return nil
+}
+
+// TouchDevice records a token refresh from an active device, unknown and
+// revoked devices are left alone.
+func (u *User) TouchDevice(deviceId string, userAgent string, ip string, now time.Time) {
+	device := u.Device(deviceId)
+	if device == nil || !device.IsActive() {
+		return
+	}
+
+	device.seen(userAgent, ip, now)
+	u.deviceChanged(deviceId)
+}
+
+// RevokeDevice ends the session of the device and records DeviceRevoked.
+// Revoking a revoked device does nothing.
+func (u *User) RevokeDevice(deviceId string, now time.Time) error {
+	device := u.Device(deviceId)
+	if device == nil {
+		return ddgo.NewNotFoundError(fmt.Sprintf("device %s not found", deviceId))
+	}
+
+	if device.IsActive() {
+		device.revoke(now)
+		u.deviceChanged(deviceId)
+		u.AddDomainEvent(events.NewDeviceRevoked(u.Uuid(), deviceId))
+	}
+	return nil
+}
+
+// RevokeOtherDevices revokes every active device but keep and returns the
+// ids of the revoked ones.
+func (u *User) RevokeOtherDevices(keep string, now time.Time) []string {
+	revoked := make([]string, 0, len(u.devices))
+	for _, device := range u.devices {
+		if device.DeviceId() == keep || !device.IsActive() {
+			continue
+		}
+
+		device.revoke(now)
+		u.deviceChanged(device.DeviceId())
+		u.AddDomainEvent(events.NewDeviceRevoked(u.Uuid(), device.DeviceId()))
+		revoked = append(revoked, device.DeviceId())
+	}
+	return revoked
+}
+
+func (u *User) deviceChanged(deviceId string) {
+	if !slices.Contains(u.changedDevices, deviceId) {
+		u.changedDevices = append(u.changedDevices, deviceId)
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+type UserStatus string
+
+const (
+	UserStatusActive   UserStatus = "active"
+	UserStatusInactive UserStatus = "inactive"
+	UserStatusDeleted  UserStatus = "deleted"
+)
+
+// userStatusTransitions lists where each status may go, deleted is final.
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	UserStatusActive:   {UserStatusInactive, UserStatusDeleted},
+	UserStatusInactive: {UserStatusActive, UserStatusDeleted},
+}
+
+func (s UserStatus) valid() bool {
+	return s == UserStatusActive || s == UserStatusInactive || s == UserStatusDeleted
+}
+
+func (s UserStatus) canBecome(next UserStatus) bool {
+	for _, allowed := range userStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,12 @@
 package domain_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	domain "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/events"
 )
 
 func validPersonPropsForUserTest() *domain.PersonProps {
@@ -13,12 +16,12 @@ func validPersonPropsForUserTest() *domain.PersonProps {
 		ContactType: "email",
 	})
 	document, _ := domain.NewDocument(&domain.DocumentProps{
-		Value: "123.456.789-00",
+		Value: "529.982.247-25",
 	})
 	return &domain.PersonProps{
 		UuId:      "person-uuid-1",
 		Name:      "John Doe",
-		BirthDate: "1990-01-01",
+		BirthDate: newTestBirthDate("1990-01-01"),
 		Contacts:  []*domain.Contact{contact},
 		Document:  document,
 	}
@@ -29,13 +32,24 @@ func validPerson() *domain.Person {
 	return p
 }
 
+func validPassword(hash string) *domain.Password {
+	p, _ := domain.NewPassword(&domain.PasswordProps{Hash: hash})
+	return p
+}
+
+type fakeVerifier struct{}
+
+func (fakeVerifier) Verify(hash string, plain string) bool {
+	return hash == "hashed:"+plain
+}
+
 func TestNewUser(t *testing.T) {
 	t.Run("Should success when create user with valid data", func(t *testing.T) {
 		t.Parallel()
 		props := &domain.UserProps{
 			UuId:     "user-uuid-1",
 			Username: "johndoe",
-			Password: "s3cr3t",
+			Password: validPassword("s3cr3t"),
 			Person:   validPerson(),
 		}
 
@@ -54,7 +68,7 @@ func TestNewUser(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "user-uuid-1",
 			Username: "",
-			Password: "s3cr3t",
+			Password: validPassword("s3cr3t"),
 			Person:   validPerson(),
 		}
 
@@ -73,7 +87,7 @@ func TestNewUser(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "user-uuid-1",
 			Username: "johndoe",
-			Password: "",
+			Password: nil,
 			Person:   validPerson(),
 		}
 
@@ -92,7 +106,7 @@ func TestNewUser(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "user-uuid-1",
 			Username: "johndoe",
-			Password: "s3cr3t",
+			Password: validPassword("s3cr3t"),
 			Person:   nil,
 		}
 
@@ -107,7 +121,7 @@ func TestNewUser(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "",
 			Username: "johndoe",
-			Password: "s3cr3t",
+			Password: validPassword("s3cr3t"),
 			Person:   validPerson(),
 		}
 
@@ -126,7 +140,7 @@ func TestNewUser(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "",
 			Username: "",
-			Password: "",
+			Password: nil,
 			Person:   nil,
 		}
 
@@ -147,7 +161,7 @@ func TestUserGetters(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "user-uuid-1",
 			Username: "johndoe",
-			Password: "s3cr3t",
+			Password: validPassword("s3cr3t"),
 			Person:   validPerson(),
 		}
 
@@ -157,8 +171,8 @@ func TestUserGetters(t *testing.T) {
 			t.Errorf("Should return 'johndoe', got: %v", user.Username())
 		}
 
-		if user.Password() != "s3cr3t" {
-			t.Errorf("Should return 's3cr3t', got: %v", user.Password())
+		if user.Password().Hash() != "s3cr3t" {
+			t.Errorf("Should return 's3cr3t', got: %v", user.Password().Hash())
 		}
 	})
 
@@ -168,7 +182,7 @@ func TestUserGetters(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "user-uuid-1",
 			Username: "johndoe",
-			Password: "s3cr3t",
+			Password: validPassword("s3cr3t"),
 			Person:   p,
 		}
 
@@ -190,15 +204,683 @@ func TestUserSetters(t *testing.T) {
 		props := &domain.UserProps{
 			UuId:     "user-uuid-1",
 			Username: "johndoe",
-			Password: "s3cr3t",
+			Password: validPassword("s3cr3t"),
 			Person:   validPerson(),
 		}
 
 		user, _ := domain.NewUser(props)
-		user.SetPassword("newpassword")
+		user.SetPassword(validPassword("newpassword"))
+
+		if user.Password().Hash() != "newpassword" {
+			t.Errorf("Should return the updated password, got: %v", user.Password().Hash())
+		}
+	})
+}
+
+func TestUserVerifyPassword(t *testing.T) {
+	user, _ := domain.NewUser(&domain.UserProps{
+		UuId:     "user-uuid-1",
+		Username: "johndoe",
+		Password: validPassword("hashed:S3cr3t!pass"),
+		Person:   validPerson(),
+	})
+
+	tests := []struct {
+		name     string
+		plain    string
+		expected bool
+	}{
+		{"Should accept the matching plaintext", "S3cr3t!pass", true},
+		{"Should reject a different plaintext", "wrong", false},
+		{"Should reject an empty plaintext", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := user.VerifyPassword(tt.plain, fakeVerifier{}); got != tt.expected {
+				t.Errorf("Should return %v, got: %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRegisterUser(t *testing.T) {
+	t.Run("Should record UserCreated when registering a user", func(t *testing.T) {
+		t.Parallel()
+		person := validPerson()
+		user, err := domain.RegisterUser(&domain.UserProps{
+			UuId:     "user-uuid-1",
+			Username: "johndoe",
+			Password: validPassword("s3cr3t"),
+			Person:   person,
+		})
+		if err != nil {
+			t.Fatalf("Should register user, got: %v", err)
+		}
+
+		if len(user.DomainEvents()) != 1 {
+			t.Fatalf("Should record 1 event, got: %d", len(user.DomainEvents()))
+		}
+
+		for _, event := range user.DomainEvents() {
+			created, ok := event.(*events.UserCreated)
+			if !ok {
+				t.Fatalf("Should record UserCreated, got: %T", event)
+			}
+
+			expected := events.UserCreatedPayload{UserId: "user-uuid-1", Username: "johndoe", PersonId: person.Uuid()}
+			if created.Payload() != expected {
+				t.Errorf("Should return payload %v, got: %v", expected, created.Payload())
+			}
+		}
+	})
+
+	t.Run("Should not record events when rehydrating a user", func(t *testing.T) {
+		t.Parallel()
+		user, _ := domain.NewUser(&domain.UserProps{
+			UuId:     "user-uuid-1",
+			Username: "johndoe",
+			Password: validPassword("s3cr3t"),
+			Person:   validPerson(),
+		})
+
+		if len(user.DomainEvents()) != 0 {
+			t.Errorf("Should record no events, got: %d", len(user.DomainEvents()))
+		}
+	})
+}
+
+func existingUser() *domain.User {
+	user, _ := domain.NewUser(&domain.UserProps{
+		UuId:     "user-uuid-1",
+		Username: "johndoe",
+		Password: validPassword("s3cr3t"),
+		Person:   validPerson(),
+		Version:  3,
+	})
+	return user
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func TestUserUpdateProfile(t *testing.T) {
+	t.Run("Should record UserProfileUpdated with the changed fields", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		err := user.UpdateProfile(&domain.ProfileChanges{
+			Name:      stringPointer("Jane Doe"),
+			BirthDate: stringPointer("1990-01-01"),
+		})
+		if err != nil {
+			t.Fatalf("Should update profile, got: %v", err)
+		}
+
+		if user.Person().Name() != "Jane Doe" {
+			t.Errorf("Should return name Jane Doe, got: %s", user.Person().Name())
+		}
+
+		for _, event := range user.DomainEvents() {
+			updated, ok := event.(*events.UserProfileUpdated)
+			if !ok {
+				t.Fatalf("Should record UserProfileUpdated, got: %T", event)
+			}
+
+			payload := updated.Payload().(events.UserProfileUpdatedPayload)
+			if len(payload.Changes) != 1 || payload.Changes[0] != "name" {
+				t.Errorf("Should return changes [name], got: %v", payload.Changes)
+			}
+		}
+
+		if len(user.DomainEvents()) != 1 {
+			t.Errorf("Should record 1 event, got: %d", len(user.DomainEvents()))
+		}
+	})
+
+	t.Run("Should keep contact ids when the list has the same contacts", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		err := user.UpdateProfile(&domain.ProfileChanges{
+			Contacts: []*domain.ContactProps{
+				{UuId: "new-uuid-1", Description: "test@EXAMPLE.com", ContactType: "email", Main: true},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Should update profile, got: %v", err)
+		}
+
+		if user.Person().Contacts()[0].Uuid() != "contact-uuid-1" {
+			t.Errorf("Should keep contact-uuid-1, got: %s", user.Person().Contacts()[0].Uuid())
+		}
+
+		if len(user.DomainEvents()) != 0 {
+			t.Errorf("Should record no events, got: %d", len(user.DomainEvents()))
+		}
+	})
+
+	t.Run("Should remove every contact with an empty list", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		err := user.UpdateProfile(&domain.ProfileChanges{Contacts: []*domain.ContactProps{}})
+		if err != nil {
+			t.Fatalf("Should update profile, got: %v", err)
+		}
+
+		if len(user.Person().Contacts()) != 0 {
+			t.Errorf("Should remove contacts, got: %d", len(user.Person().Contacts()))
+		}
+	})
+
+	t.Run("Should keep the profile when a change is invalid", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		err := user.UpdateProfile(&domain.ProfileChanges{
+			Name:      stringPointer("Jane Doe"),
+			BirthDate: stringPointer("not-a-date"),
+		})
+		if err == nil {
+			t.Fatal("Should return error for invalid birth date")
+		}
+
+		if user.Person().Name() != "John Doe" || len(user.DomainEvents()) != 0 {
+			t.Errorf("Should keep the profile, got name %s and %d events", user.Person().Name(), len(user.DomainEvents()))
+		}
+	})
+
+	t.Run("Should reject duplicated contacts", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		err := user.UpdateProfile(&domain.ProfileChanges{
+			Contacts: []*domain.ContactProps{
+				{UuId: "uuid-1", Description: "a@example.com", ContactType: "email", Main: true},
+				{UuId: "uuid-2", Description: "a@example.com", ContactType: "email"},
+			},
+		})
+		if err == nil {
+			t.Error("Should return error for duplicated contacts")
+		}
+	})
+}
+
+func TestUserChangeUsername(t *testing.T) {
+	tests := []struct {
+		description string
+		username    string
+		wantErr     bool
+		wantEvents  int
+	}{
+		{"Should record UsernameChanged for a new username", "janedoe", false, 1},
+		{"Should do nothing when the username is the same", "johndoe", false, 0},
+		{"Should return error for empty username", "", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			user := existingUser()
+			err := user.ChangeUsername(tt.username)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Should return error %v, got: %v", tt.wantErr, err)
+			}
+
+			if len(user.DomainEvents()) != tt.wantEvents {
+				t.Errorf("Should record %d events, got: %d", tt.wantEvents, len(user.DomainEvents()))
+			}
+
+			if user.Version() != 3 {
+				t.Errorf("Should keep version 3, got: %d", user.Version())
+			}
+		})
+	}
+}
+
+func TestUserStatus(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		description string
+		transitions func(user *domain.User) error
+		wantErr     bool
+		wantStatus  domain.UserStatus
+		wantEvents  int
+	}{
+		{
+			description: "Should deactivate an active user",
+			transitions: func(user *domain.User) error { return user.Deactivate() },
+			wantStatus:  domain.UserStatusInactive,
+			wantEvents:  1,
+		},
+		{
+			description: "Should reactivate an inactive user",
+			transitions: func(user *domain.User) error {
+				user.Deactivate()
+				return user.Reactivate()
+			},
+			wantStatus: domain.UserStatusActive,
+			wantEvents: 2,
+		},
+		{
+			description: "Should return error reactivating an active user",
+			transitions: func(user *domain.User) error { return user.Reactivate() },
+			wantErr:     true,
+			wantStatus:  domain.UserStatusActive,
+		},
+		{
+			description: "Should delete an inactive user",
+			transitions: func(user *domain.User) error {
+				user.Deactivate()
+				return user.Delete(now)
+			},
+			wantStatus: domain.UserStatusDeleted,
+			wantEvents: 2,
+		},
+		{
+			description: "Should return error reactivating a deleted user",
+			transitions: func(user *domain.User) error {
+				user.Delete(now)
+				return user.Reactivate()
+			},
+			wantErr:    true,
+			wantStatus: domain.UserStatusDeleted,
+			wantEvents: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			user := existingUser()
+			err := tt.transitions(user)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Should return error %v, got: %v", tt.wantErr, err)
+			}
+
+			if user.Status() != tt.wantStatus {
+				t.Errorf("Should return status %s, got: %s", tt.wantStatus, user.Status())
+			}
+
+			if len(user.DomainEvents()) != tt.wantEvents {
+				t.Errorf("Should record %d events, got: %d", tt.wantEvents, len(user.DomainEvents()))
+			}
+		})
+	}
+
+	t.Run("Should return ErrStatusTransition deactivating an inactive user", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		user.Deactivate()
+		if err := user.Deactivate(); !errors.Is(err, domain.ErrStatusTransition) {
+			t.Errorf("Should return ErrStatusTransition, got: %v", err)
+		}
+	})
+
+	t.Run("Should default to active and keep the deletion time", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		if !user.IsActive() {
+			t.Fatalf("Should be active, got: %s", user.Status())
+		}
+
+		user.Delete(now)
+		if user.IsActive() || user.DeletedAt() == nil || !user.DeletedAt().Equal(now) {
+			t.Errorf("Should be deleted at %v, got: %v", now, user.DeletedAt())
+		}
+	})
+
+	t.Run("Should return error for unknown status", func(t *testing.T) {
+		t.Parallel()
+		_, err := domain.NewUser(&domain.UserProps{
+			UuId:     "user-uuid-1",
+			Username: "johndoe",
+			Password: validPassword("s3cr3t"),
+			Person:   validPerson(),
+			Status:   "banned",
+		})
+		if err == nil {
+			t.Error("Should return error for unknown status")
+		}
+	})
+}
+
+func TestUserEmailVerification(t *testing.T) {
+	policy := domain.DefaultVerificationPolicy()
+	now := time.Now()
+
+	t.Run("Should verify the email contact with the issued code", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		code, err := user.RequestVerification(policy, now)
+		if err != nil {
+			t.Fatalf("Should request verification, got: %v", err)
+		}
+
+		if len(code) != 6 || user.Verification().Hash() == code {
+			t.Fatalf("Should return a 6 digit code and keep only its hash, got: %s", code)
+		}
+
+		err = user.VerifyEmail(code, policy, now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("Should verify email, got: %v", err)
+		}
+
+		if !user.Person().Contact("contact-uuid-1").Verified() || user.Verification() != nil {
+			t.Error("Should mark the contact verified and drop the code")
+		}
+
+		if len(user.DomainEvents()) != 2 {
+			t.Errorf("Should record 2 events, got: %d", len(user.DomainEvents()))
+		}
+
+		_, err = user.RequestVerification(policy, now.Add(time.Hour))
+		if err == nil {
+			t.Error("Should return error requesting verification of a verified email")
+		}
+	})
+
+	t.Run("Should count wrong codes and stop at the maximum attempts", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		code, _ := user.RequestVerification(policy, now)
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+
+		for range policy.MaxAttempts {
+			if err := user.VerifyEmail(wrong, policy, now); err == nil {
+				t.Fatal("Should return error for wrong code")
+			}
+		}
+
+		if user.Verification().Attempts() != policy.MaxAttempts {
+			t.Errorf("Should count %d attempts, got: %d", policy.MaxAttempts, user.Verification().Attempts())
+		}
+
+		if err := user.VerifyEmail(code, policy, now); err == nil {
+			t.Error("Should refuse the right code after the maximum attempts")
+		}
+	})
+
+	t.Run("Should keep the attempts on a code resent inside the attempts window", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		code, _ := user.RequestVerification(policy, now)
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+
+		for range policy.MaxAttempts {
+			user.VerifyEmail(wrong, policy, now)
+		}
+
+		resent, err := user.RequestVerification(policy, now.Add(policy.ResendCooldown))
+		if err != nil {
+			t.Fatalf("Should resend after the cooldown, got: %v", err)
+		}
+
+		if err := user.VerifyEmail(resent, policy, now.Add(policy.ResendCooldown)); err == nil {
+			t.Error("Should refuse the resent code while the attempts are exhausted")
+		}
+
+		resent, _ = user.RequestVerification(policy, now.Add(policy.ResendCooldown+policy.AttemptsWindow))
+		if user.Verification().Attempts() != 0 {
+			t.Errorf("Should reset the attempts after the window, got: %d", user.Verification().Attempts())
+		}
+
+		if err := user.VerifyEmail(resent, policy, now.Add(policy.ResendCooldown+policy.AttemptsWindow)); err != nil {
+			t.Errorf("Should verify the code sent after the window, got: %v", err)
+		}
+	})
+
+	t.Run("Should return error for expired code", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		code, _ := user.RequestVerification(policy, now)
+
+		if err := user.VerifyEmail(code, policy, now.Add(policy.TTL)); err == nil {
+			t.Error("Should return error for expired code")
+		}
+	})
+
+	t.Run("Should only resend after the cooldown", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		user.RequestVerification(policy, now)
+
+		if _, err := user.RequestVerification(policy, now.Add(policy.ResendCooldown/2)); err == nil {
+			t.Error("Should return error resending inside the cooldown")
+		}
+
+		if _, err := user.RequestVerification(policy, now.Add(policy.ResendCooldown)); err != nil {
+			t.Errorf("Should resend after the cooldown, got: %v", err)
+		}
+	})
+}
+
+func TestUserPasswordReset(t *testing.T) {
+	ttl := domain.DefaultPasswordResetTTL
+	now := time.Now()
+
+	t.Run("Should replace the password once with the issued token", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		token, err := user.RequestPasswordReset(ttl, now)
+		if err != nil {
+			t.Fatalf("Should request password reset, got: %v", err)
+		}
+
+		if token == "" || user.PasswordReset().Hash() != domain.HashPasswordResetToken(token) {
+			t.Fatalf("Should return the token and keep only its hash, got: %s", token)
+		}
+
+		err = user.ResetPassword(token, validPassword("hashed:n3w"), now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("Should reset password, got: %v", err)
+		}
+
+		if user.Password().Hash() != "hashed:n3w" || user.PasswordReset() != nil {
+			t.Error("Should set the new password and drop the reset")
+		}
+
+		if len(user.DomainEvents()) != 1 {
+			t.Fatalf("Should record 1 event, got: %d", len(user.DomainEvents()))
+		}
+
+		for _, event := range user.DomainEvents() {
+			changed, ok := event.(*events.PasswordChanged)
+			if !ok {
+				t.Fatalf("Should record PasswordChanged, got: %T", event)
+			}
+
+			expected := events.PasswordChangedPayload{UserId: "user-uuid-1", Reason: events.PasswordChangedByReset}
+			if changed.Payload() != expected {
+				t.Errorf("Should carry the reset reason, got: %+v", changed.Payload())
+			}
+		}
+
+		if err := user.ResetPassword(token, validPassword("hashed:other"), now.Add(time.Minute)); err == nil {
+			t.Error("Should return error using the token twice")
+		}
+	})
+
+	t.Run("Should return error for wrong or expired token", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		token, _ := user.RequestPasswordReset(ttl, now)
+
+		if err := user.ResetPassword("wrong", validPassword("hashed:n3w"), now); err == nil {
+			t.Error("Should return error for wrong token")
+		}
+
+		if err := user.ResetPassword(token, validPassword("hashed:n3w"), now.Add(ttl)); err == nil {
+			t.Error("Should return error for expired token")
+		}
+
+		if user.Password().Hash() != "s3cr3t" || len(user.DomainEvents()) != 0 {
+			t.Error("Should keep the password and record no event")
+		}
+	})
+
+	t.Run("Should replace the pending reset on a new request", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		first, _ := user.RequestPasswordReset(ttl, now)
+		second, _ := user.RequestPasswordReset(ttl, now)
+
+		if err := user.ResetPassword(first, validPassword("hashed:n3w"), now); err == nil {
+			t.Error("Should return error for the replaced token")
+		}
+
+		if err := user.ResetPassword(second, validPassword("hashed:n3w"), now); err != nil {
+			t.Errorf("Should reset with the last token, got: %v", err)
+		}
+	})
+
+	t.Run("Should return error when there is no reset", func(t *testing.T) {
+		t.Parallel()
+		if err := existingUser().ResetPassword("token", validPassword("hashed:n3w"), now); err == nil {
+			t.Error("Should return error without a pending reset")
+		}
+	})
+}
+
+func TestUserChangePassword(t *testing.T) {
+	newUser := func() *domain.User {
+		user, _ := domain.NewUser(&domain.UserProps{
+			UuId:     "user-uuid-1",
+			Username: "johndoe",
+			Password: validPassword("hashed:0ld"),
+			Person:   validPerson(),
+		})
+		return user
+	}
+
+	t.Run("Should change the password when the current one matches", func(t *testing.T) {
+		t.Parallel()
+		user := newUser()
+		user.RequestPasswordReset(domain.DefaultPasswordResetTTL, time.Now())
+
+		err := user.ChangePassword("0ld", fakeVerifier{}, validPassword("hashed:n3w"))
+		if err != nil {
+			t.Fatalf("Should change password, got: %v", err)
+		}
+
+		if user.Password().Hash() != "hashed:n3w" || user.PasswordReset() != nil {
+			t.Error("Should set the new password and drop the pending reset")
+		}
+
+		if len(user.DomainEvents()) != 1 {
+			t.Errorf("Should record 1 event, got: %d", len(user.DomainEvents()))
+		}
+	})
+
+	t.Run("Should return error when the current password is wrong", func(t *testing.T) {
+		t.Parallel()
+		user := newUser()
+
+		if err := user.ChangePassword("wrong", fakeVerifier{}, validPassword("hashed:n3w")); err == nil {
+			t.Error("Should return error for wrong current password")
+		}
+
+		if user.Password().Hash() != "hashed:0ld" || len(user.DomainEvents()) != 0 {
+			t.Error("Should keep the password and record no event")
+		}
+	})
+}
+
+func TestUserDevices(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Should register a new device once and refresh a known one", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		if err := user.RegisterDevice("phone", "app/1.0", "10.0.0.1", now); err != nil {
+			t.Fatalf("Should register device, got: %v", err)
+		}
+
+		if err := user.RegisterDevice("phone", "app/1.1", "10.0.0.2", now.Add(time.Hour)); err != nil {
+			t.Fatalf("Should register known device, got: %v", err)
+		}
+
+		device := user.Device("phone")
+		if len(user.Devices()) != 1 || device.UserAgent() != "app/1.1" || device.LastIp() != "10.0.0.2" || !device.RegisteredAt().Equal(now) {
+			t.Errorf("Should keep one device with the last seen data, got: %+v", user.Devices())
+		}
+
+		if len(user.DomainEvents()) != 1 || len(user.ChangedDevices()) != 1 {
+			t.Errorf("Should record 1 event and 1 changed device, got: %d %d", len(user.DomainEvents()), len(user.ChangedDevices()))
+		}
+	})
+
+	t.Run("Should return error for an invalid device id", func(t *testing.T) {
+		t.Parallel()
+		if err := existingUser().RegisterDevice("", "app/1.0", "10.0.0.1", now); err == nil {
+			t.Error("Should return error for empty device id")
+		}
+	})
+
+	t.Run("Should revoke a device and bring it back on login", func(t *testing.T) {
+		t.Parallel()
+		user := existingUser()
+		user.RegisterDevice("phone", "app/1.0", "10.0.0.1", now)
+
+		if err := user.RevokeDevice("phone", now); err != nil {
+			t.Fatalf("Should revoke device, got: %v", err)
+		}
+
+		if err := user.RevokeDevice("phone", now); err != nil {
+			t.Fatalf("Should ignore revoking twice, got: %v", err)
+		}
+
+		if len(user.ActiveDevices()) != 0 || len(user.DomainEvents()) != 2 {
+			t.Errorf("Should have no active device and 2 events, got: %d %d", len(user.ActiveDevices()), len(user.DomainEvents()))
+		}
+
+		user.TouchDevice("phone", "app/2.0", "10.0.0.9", now.Add(time.Minute))
+		if user.Device("phone").IsActive() || user.Device("phone").UserAgent() != "app/1.0" {
+			t.Error("Should not touch a revoked device")
+		}
+
+		user.RegisterDevice("phone", "app/2.0", "10.0.0.9", now.Add(time.Minute))
+		if !user.Device("phone").IsActive() {
+			t.Error("Should bring the device back on login")
+		}
+	})
+
+	t.Run("Should return error revoking an unknown device", func(t *testing.T) {
+		t.Parallel()
+		if err := existingUser().RevokeDevice("unknown", now); err == nil {
+			t.Error("Should return error for unknown device")
+		}
+	})
+
+	t.Run("Should revoke every other active device", func(t *testing.T) {
+		t.Parallel()
+		user, _ := domain.NewBuilder().
+			WithUuId("user-uuid-1").
+			WithUsername("johndoe").
+			WithPassword("s3cr3t").
+			WithPerson(&domain.WithPersonProps{Person: validPersonPropsForUserTest()}).
+			WithDevices([]*domain.DeviceProps{
+				{DeviceId: "phone", LastSeenAt: now, RegisteredAt: now},
+				{DeviceId: "laptop", LastSeenAt: now, RegisteredAt: now},
+				{DeviceId: "tablet", LastSeenAt: now, RegisteredAt: now, RevokedAt: &now},
+			}).
+			Build()
+
+		revoked := user.RevokeOtherDevices("phone", now)
+		if len(revoked) != 1 || revoked[0] != "laptop" {
+			t.Errorf("Should revoke only the laptop, got: %v", revoked)
+		}
+
+		if active := user.ActiveDevices(); len(active) != 1 || active[0].DeviceId() != "phone" {
+			t.Errorf("Should keep the phone active, got: %v", active)
+		}
 
-		if user.Password() != "newpassword" {
-			t.Errorf("Should return the updated password, got: %v", user.Password())
+		if len(user.ChangedDevices()) != 1 {
+			t.Errorf("Should change 1 device, got: %d", len(user.ChangedDevices()))
 		}
 	})
 }
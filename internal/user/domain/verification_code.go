@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+const verificationCodeDigits = 6
+
+type VerificationCodeProps struct {
+	Hash      string `domainValidator:"required"`
+	ContactId string `domainValidator:"required"`
+	ExpiresAt time.Time
+	SentAt    time.Time
+	Attempts  int
+}
+
+// VerificationCode is the pending proof that a user owns an email contact.
+// Only its hash is kept, the plain code goes to the user once.
+type VerificationCode struct {
+	hash      string
+	contactId string
+	expiresAt time.Time
+	sentAt    time.Time
+	attempts  int
+}
+
+// VerificationPolicy bounds how long a code lives, how often it can be sent
+// again and how many wrong guesses it takes. The wrong guesses carry over to
+// a code issued less than AttemptsWindow after the previous one, so asking
+// for new codes does not buy more guesses.
+type VerificationPolicy struct {
+	TTL            time.Duration
+	ResendCooldown time.Duration
+	MaxAttempts    int
+	AttemptsWindow time.Duration
+}
+
+func DefaultVerificationPolicy() *VerificationPolicy {
+	return &VerificationPolicy{
+		TTL:            15 * time.Minute,
+		ResendCooldown: time.Minute,
+		MaxAttempts:    5,
+		AttemptsWindow: time.Hour,
+	}
+}
+
+func NewVerificationCode(props *VerificationCodeProps) (*VerificationCode, error) {
+	err := validateVerificationCode(props)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerificationCode{
+		hash:      props.Hash,
+		contactId: props.ContactId,
+		expiresAt: props.ExpiresAt,
+		sentAt:    props.SentAt,
+		attempts:  props.Attempts,
+	}, nil
+}
+
+func issueVerificationCode(userId string, contactId string, policy *VerificationPolicy, now time.Time) (*VerificationCode, string, error) {
+	limit := big.NewInt(1)
+	for range verificationCodeDigits {
+		limit.Mul(limit, big.NewInt(10))
+	}
+
+	number, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return nil, "", ddgo.NewInternalError("Error when generating verification code")
+	}
+
+	plain := fmt.Sprintf("%0*d", verificationCodeDigits, number)
+	code, err := NewVerificationCode(&VerificationCodeProps{
+		Hash:      hashVerificationCode(userId, plain),
+		ContactId: contactId,
+		ExpiresAt: now.Add(policy.TTL),
+		SentAt:    now,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return code, plain, nil
+}
+
+// hashVerificationCode salts the code with the user id, so equal codes of
+// different users do not share a hash.
+func hashVerificationCode(userId string, plain string) string {
+	sum := sha256.Sum256([]byte(userId + ":" + plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func validateVerificationCode(props *VerificationCodeProps) error {
+	validator := ddgo.ValidatorInstance()
+	validationErrors, faliedValidation := validator.Validate(props)
+	if faliedValidation != nil {
+		return ddgo.NewInternalError("Error when validating verification code data")
+	}
+
+	if len(validationErrors) > 0 {
+		validationResult, failed := json.Marshal(validationErrors)
+		if failed != nil {
+			return ddgo.NewInternalError("Error when marshaling validation errors")
+		}
+		return ddgo.NewInvalidDataError(string(validationResult))
+	}
+
+	return nil
+}
+
+func (c *VerificationCode) matches(userId string, plain string) bool {
+	return subtle.ConstantTimeCompare([]byte(c.hash), []byte(hashVerificationCode(userId, plain))) == 1
+}
+
+func (c *VerificationCode) Hash() string {
+	return c.hash
+}
+
+// ContactId is the email contact the code was sent to.
+func (c *VerificationCode) ContactId() string {
+	return c.contactId
+}
+
+func (c *VerificationCode) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+func (c *VerificationCode) SentAt() time.Time {
+	return c.sentAt
+}
+
+// Attempts counts the wrong codes tried so far.
+func (c *VerificationCode) Attempts() int {
+	return c.attempts
+}
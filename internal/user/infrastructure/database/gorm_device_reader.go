@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listdevices"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+)
+
+// GormDeviceReader serves the device listing straight from the tables, a
+// device counts as a session while one of its refresh tokens is usable.
+type GormDeviceReader struct {
+	db *gorm.DB
+}
+
+type deviceListRow struct {
+	DeviceId         string
+	UserAgent        string
+	LastIp           string
+	LastSeenAt       time.Time
+	CreatedAt        time.Time
+	SessionExpiresAt time.Time
+}
+
+func NewGormDeviceReader(db *gorm.DB) *GormDeviceReader {
+	return &GormDeviceReader{db: db}
+}
+
+func (r *GormDeviceReader) ListActive(ctx context.Context, userId string, now time.Time) ([]*listdevices.Item, error) {
+	var rows []deviceListRow
+	err := r.db.WithContext(ctx).
+		Table(`"hex-api-go".users_devices d`).
+		Joins(`JOIN "hex-api-go".users u ON u.id = d.user_id`).
+		Joins(`JOIN "hex-api-go".users_refresh_tokens t ON t.user_device_id = d.id
+			AND t.revoked_at IS NULL AND t.rotated_at IS NULL AND t.deleted_at IS NULL AND t.expires_at > ?`, now).
+		Where("u.uuid = ? AND d.revoked_at IS NULL AND d.deleted_at IS NULL", userId).
+		Group("d.id").
+		Select("d.device_id, d.user_agent, d.last_ip, d.last_seen_at, d.created_at, MAX(t.expires_at) AS session_expires_at").
+		Order("d.last_seen_at DESC, d.id").
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, postgres.TranslateError("list devices", err)
+	}
+
+	items := make([]*listdevices.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, &listdevices.Item{
+			DeviceId:         row.DeviceId,
+			UserAgent:        row.UserAgent,
+			LastIp:           row.LastIp,
+			LastSeenAt:       row.LastSeenAt,
+			RegisteredAt:     row.CreatedAt,
+			SessionExpiresAt: row.SessionExpiresAt,
+		})
+	}
+
+	return items, nil
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewGormGroupRepository(db *gorm.DB) *GormGroupRepository {
+	return &GormGroupRepository{db: db}
+}
+
+func (r *GormGroupRepository) Create(ctx context.Context, group *domain.Group) error {
+	tx := postgres.Begin(ctx, r.db)
+	entity := groupToDatabase(group)
+	err := gorm.G[UsersGroups](tx).Create(ctx, entity)
+	if err != nil {
+		tx.Rollback()
+		return postgres.TranslateError("create group", err)
+	}
+
+	if err := r.syncChildren(ctx, tx, entity.ID, group); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+func (r *GormGroupRepository) FindByUuid(ctx context.Context, uuid string) (*domain.Group, error) {
+	entity, err := r.findEntity(ctx, postgres.Conn(ctx, r.db), uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := gorm.G[UserGroupsPermissions](postgres.Conn(ctx, r.db)).
+		Preload("ApiRouteApplication", nil).
+		Where("user_group_id = ?", entity.ID).
+		Find(ctx)
+	if err != nil {
+		return nil, postgres.TranslateError("find group permissions", err)
+	}
+
+	members, err := gorm.G[UserGroupUser](postgres.Conn(ctx, r.db)).
+		Preload("User", nil).
+		Where(`user_group_id = ? AND user_id IN (SELECT id FROM "hex-api-go".users WHERE deleted_at IS NULL)`, entity.ID).
+		Find(ctx)
+	if err != nil {
+		return nil, postgres.TranslateError("find group members", err)
+	}
+
+	group, err := groupToDomain(entity, permissions, members)
+	if err != nil {
+		return nil, ddgo.NewInternalError(fmt.Sprintf("Error to rebuild group %s: %s", uuid, err.Error()))
+	}
+
+	return group, nil
+}
+
+func (r *GormGroupRepository) Update(ctx context.Context, group *domain.Group) error {
+	tx := postgres.Begin(ctx, r.db)
+	entity, err := r.findEntity(ctx, tx, group.Uuid())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = gorm.G[UsersGroups](tx).Where("id = ?", entity.ID).Update(ctx, "name", group.Name())
+	if err != nil {
+		tx.Rollback()
+		return postgres.TranslateError("update group", err)
+	}
+
+	if err := r.syncChildren(ctx, tx, entity.ID, group); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+func (r *GormGroupRepository) Delete(ctx context.Context, group *domain.Group) error {
+	tx := postgres.Begin(ctx, r.db)
+	entity, err := r.findEntity(ctx, tx, group.Uuid())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := r.deleteChildren(ctx, tx, entity.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = gorm.G[UsersGroups](tx).Where("id = ?", entity.ID).Delete(ctx)
+	if err != nil {
+		tx.Rollback()
+		return postgres.TranslateError("delete group", err)
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+func (r *GormGroupRepository) findEntity(ctx context.Context, db *gorm.DB, uuid string) (*UsersGroups, error) {
+	entity, err := gorm.G[UsersGroups](db).Where("uuid = ?", uuid).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ddgo.NewNotFoundError(fmt.Sprintf("group %s not found", uuid))
+	}
+
+	if err != nil {
+		return nil, postgres.TranslateError("find group", err)
+	}
+
+	return &entity, nil
+}
+
+// syncChildren rewrites permissions and memberships from the aggregate, which
+// is simpler than diffing and keeps the tables an exact copy of it.
+func (r *GormGroupRepository) syncChildren(ctx context.Context, tx *gorm.DB, groupId uint, group *domain.Group) error {
+	if err := r.deleteChildren(ctx, tx, groupId); err != nil {
+		return err
+	}
+
+	for _, permission := range group.Permissions() {
+		application := ApiRouteApplications{Name: permission.Resource()}
+		err := tx.WithContext(ctx).
+			Where(&ApiRouteApplications{Name: permission.Resource()}).
+			FirstOrCreate(&application).Error
+		if err != nil {
+			return postgres.TranslateError("register api route application", err)
+		}
+
+		err = tx.WithContext(ctx).Omit(clause.Associations).Create(&UserGroupsPermissions{
+			ApiRoutApplicationId: application.ID,
+			Action:               permission.Action(),
+			UserGroupId:          groupId,
+		}).Error
+		if err != nil {
+			return postgres.TranslateError("grant group permission", err)
+		}
+	}
+
+	return r.syncMembers(ctx, tx, groupId, group.Members())
+}
+
+func (r *GormGroupRepository) syncMembers(ctx context.Context, tx *gorm.DB, groupId uint, members []*domain.GroupMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(members))
+	for _, member := range members {
+		uuids = append(uuids, member.UserId())
+	}
+
+	users, err := gorm.G[Users](tx).Select("id", "uuid").Where("uuid IN ?", uuids).Find(ctx)
+	if err != nil {
+		return postgres.TranslateError("find group users", err)
+	}
+
+	userIds := make(map[string]uint, len(users))
+	for _, user := range users {
+		userIds[user.Uuid] = user.ID
+	}
+
+	rows := make([]UserGroupUser, 0, len(members))
+	mainUserIds := make([]uint, 0, 1)
+	for _, member := range members {
+		userId, ok := userIds[member.UserId()]
+		if !ok {
+			return ddgo.NewNotFoundError(fmt.Sprintf("user %s not found", member.UserId()))
+		}
+
+		rows = append(rows, UserGroupUser{Main: member.Main(), UsersID: userId, UsersGroupsID: groupId})
+		if member.Main() {
+			mainUserIds = append(mainUserIds, userId)
+		}
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&rows).Error; err != nil {
+		return postgres.TranslateError("add group users", err)
+	}
+
+	if len(mainUserIds) == 0 {
+		return nil
+	}
+
+	err = tx.WithContext(ctx).
+		Model(&UserGroupUser{}).
+		Where("user_id IN ? AND user_group_id <> ?", mainUserIds, groupId).
+		Update("main", false).Error
+	if err != nil {
+		return postgres.TranslateError("update main group", err)
+	}
+
+	return nil
+}
+
+func (r *GormGroupRepository) deleteChildren(ctx context.Context, tx *gorm.DB, groupId uint) error {
+	err := tx.WithContext(ctx).Unscoped().Where("user_group_id = ?", groupId).Delete(&UserGroupsPermissions{}).Error
+	if err != nil {
+		return postgres.TranslateError("clear group permissions", err)
+	}
+
+	err = tx.WithContext(ctx).Unscoped().Where("user_group_id = ?", groupId).Delete(&UserGroupUser{}).Error
+	if err != nil {
+		return postgres.TranslateError("clear group users", err)
+	}
+
+	return nil
+}
@@ -8,18 +8,22 @@ import (
 
 type Person struct {
 	gorm.Model
+	Uuid      string           `gorm:"column:uuid;type:uuid;uniqueIndex;not null"`
 	Name      string           `gorm:"column:name;not null"`
-	Document  string           `gorm:"column:document;not null"`
-	BirthDate string           `gorm:"column:birth_date;not null"`
+	Document  string           `gorm:"column:document;uniqueIndex:idx_persons_document,where:deleted_at IS NULL;not null"`
+	BirthDate time.Time        `gorm:"column:birth_date;type:date;not null"`
+	Version   int              `gorm:"column:version;not null;default:1"`
 	Users     []Users          `gorm:"foreignKey:PersonId"`
 	Contacts  []PersonContacts `gorm:"foreignKey:PersonId"`
 }
 
 type PersonContacts struct {
 	gorm.Model
-	Contact       string `gorm:"column:contact;not null"`
-	Main          bool   `gorm:"column:main;not null; default:false"`
-	PersonId      uint   `gorm:"column:person_id;not null"`
+	Uuid          string     `gorm:"column:uuid;type:uuid;uniqueIndex;not null"`
+	Contact       string     `gorm:"column:contact;not null"`
+	Main          bool       `gorm:"column:main;not null; default:false"`
+	VerifiedAt    *time.Time `gorm:"column:verified_at"`
+	PersonId      uint       `gorm:"column:person_id;not null"`
 	Person        Person
 	ContactTypeId uint `gorm:"column:person_contact_type_id;not null"`
 	ContactType   PersonContactsType
@@ -27,33 +31,89 @@ type PersonContacts struct {
 
 type PersonContactsType struct {
 	gorm.Model
-	Name           string           `gorm:"column:name;not null"`
+	Name           string           `gorm:"column:name;uniqueIndex:idx_person_contacts_types_name;not null"`
 	PersonContacts []PersonContacts `gorm:"foreignKey:ContactTypeId"`
 }
 
 type Users struct {
 	gorm.Model
-	Username         string        `gorm:"column:username;not null"`
-	Password         string        `gorm:"column:password;not null"`
-	VerificationCode string        `gorm:"column:verification_code"`
-	UserGroups       []UsersGroups `gorm:"many2many:user_group_users;joinForeignKey:user_id;joinReferences:user_group_id"`
-	PersonId         uint          `gorm:"column:person_id;not null"`
-	Person           Person
-	Devices          []UsersDevice `gorm:"foreignKey:UserId"`
+	Uuid             string  `gorm:"column:uuid;type:uuid;uniqueIndex;not null"`
+	Username         string  `gorm:"column:username;uniqueIndex:idx_users_username,where:deleted_at IS NULL;not null"`
+	Password         string  `gorm:"column:password;not null"`
+	VerificationCode *string `gorm:"column:verification_code"`
+	// the pending email verification, VerificationCode holds the code hash
+	VerificationContactId *string    `gorm:"column:verification_contact_id;type:uuid"`
+	VerificationExpiresAt *time.Time `gorm:"column:verification_expires_at"`
+	VerificationSentAt    *time.Time `gorm:"column:verification_sent_at"`
+	VerificationAttempts  int        `gorm:"column:verification_attempts;not null;default:0"`
+	// the pending password reset, PasswordResetHash holds the token hash
+	PasswordResetHash      *string       `gorm:"column:password_reset_hash"`
+	PasswordResetExpiresAt *time.Time    `gorm:"column:password_reset_expires_at"`
+	Version                int           `gorm:"column:version;not null;default:1"`
+	Status                 string        `gorm:"column:status;not null;default:active"`
+	AnonymizedAt           *time.Time    `gorm:"column:anonymized_at"`
+	UserGroups             []UsersGroups `gorm:"many2many:user_group_users;joinForeignKey:user_id;joinReferences:user_group_id"`
+	PersonId               uint          `gorm:"column:person_id;not null"`
+	Person                 Person
+	Devices                []UsersDevice `gorm:"foreignKey:UserId"`
 }
 
 type UsersDevice struct {
 	gorm.Model
-	UserId   uint `gorm:"column:user_id;not null"`
-	User     Users
-	DeviceId string `gorm:"column:device_id;not null"`
+	UserId     uint `gorm:"column:user_id;not null;uniqueIndex:idx_users_devices_user_device"`
+	User       Users
+	DeviceId   string     `gorm:"column:device_id;not null;uniqueIndex:idx_users_devices_user_device"`
+	UserAgent  string     `gorm:"column:user_agent;not null;default:''"`
+	LastIp     string     `gorm:"column:last_ip;not null;default:''"`
+	LastSeenAt time.Time  `gorm:"column:last_seen_at;not null"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+}
+
+type UserRefreshTokens struct {
+	gorm.Model
+	Uuid         string `gorm:"column:uuid;type:uuid;uniqueIndex;not null"`
+	UserDeviceId uint   `gorm:"column:user_device_id;not null"`
+	UserDevice   UsersDevice
+	FamilyId     string     `gorm:"column:family_id;type:uuid;index;not null"`
+	TokenHash    string     `gorm:"column:token_hash;uniqueIndex;not null"`
+	ExpiresAt    time.Time  `gorm:"column:expires_at;not null"`
+	RotatedAt    *time.Time `gorm:"column:rotated_at"`
+	RevokedAt    *time.Time `gorm:"column:revoked_at"`
+}
+
+type OutboxMessages struct {
+	gorm.Model
+	Uuid          string     `gorm:"column:uuid;type:uuid;uniqueIndex;not null"`
+	EventName     string     `gorm:"column:event_name;not null"`
+	AggregateId   string     `gorm:"column:aggregate_id;type:uuid;not null"`
+	Payload       []byte     `gorm:"column:payload;type:jsonb;not null"`
+	OccurredOn    time.Time  `gorm:"column:occurred_on;not null"`
+	Version       int        `gorm:"column:version;not null;default:1"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null;index"`
+	LastError     string     `gorm:"column:last_error"`
+	SentAt        *time.Time `gorm:"column:sent_at;index"`
+}
+
+type ProcessedMessages struct {
+	gorm.Model
+	Consumer    string    `gorm:"column:consumer;not null;uniqueIndex:idx_processed_messages_consumer_event"`
+	EventId     string    `gorm:"column:event_id;not null;uniqueIndex:idx_processed_messages_consumer_event"`
+	ProcessedAt time.Time `gorm:"column:processed_at;not null"`
+}
+
+type ApiRouteApplications struct {
+	gorm.Model
+	Name        string                  `gorm:"column:name;uniqueIndex;not null"`
+	Permissions []UserGroupsPermissions `gorm:"foreignKey:ApiRoutApplicationId"`
 }
 
 type UserGroupsPermissions struct {
 	gorm.Model
-	ApiRoutApplicationId uint   `gorm:"column:api_route_application_id;not null"`
-	Action               string `gorm:"column:action;not null"`
-	UserGroupId          uint   `gorm:"column:user_group_id;not null"`
+	ApiRoutApplicationId uint                 `gorm:"column:api_route_application_id;not null"`
+	ApiRouteApplication  ApiRouteApplications `gorm:"foreignKey:ApiRoutApplicationId"`
+	Action               string               `gorm:"column:action;not null"`
+	UserGroupId          uint                 `gorm:"column:user_group_id;not null"`
 	UserGroup            UsersGroups
 }
 
@@ -64,10 +124,12 @@ type UserGroupUser struct {
 	Main          bool           `gorm:"column:main;not null; default:false"`
 	UsersID       uint           `gorm:"column:user_id;primaryKey"`
 	UsersGroupsID uint           `gorm:"column:user_group_id;primaryKey"`
+	User          Users          `gorm:"foreignKey:UsersID"`
 }
 
 type UsersGroups struct {
 	gorm.Model
+	Uuid        string                  `gorm:"column:uuid;type:uuid;uniqueIndex;not null"`
 	Name        string                  `gorm:"column:name;not null"`
 	Users       []Users                 `gorm:"many2many:user_group_users;joinForeignKey:user_group_id;joinReferences:user_id"`
 	Permissions []UserGroupsPermissions `gorm:"foreignKey:UserGroupId"`
@@ -89,6 +151,10 @@ func (UsersGroups) TableName() string {
 	return "hex-api-go.users_groups"
 }
 
+func (ApiRouteApplications) TableName() string {
+	return "hex-api-go.api_route_applications"
+}
+
 func (UserGroupsPermissions) TableName() string {
 	return "hex-api-go.user_groups_permissions"
 }
@@ -104,3 +170,15 @@ func (PersonContactsType) TableName() string {
 func (UsersDevice) TableName() string {
 	return "hex-api-go.users_devices"
 }
+
+func (UserRefreshTokens) TableName() string {
+	return "hex-api-go.users_refresh_tokens"
+}
+
+func (OutboxMessages) TableName() string {
+	return "hex-api-go.outbox_messages"
+}
+
+func (ProcessedMessages) TableName() string {
+	return "hex-api-go.processed_messages"
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/outbox"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+)
+
+type GormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOutboxRepository(db *gorm.DB) *GormOutboxRepository {
+	return &GormOutboxRepository{db: db}
+}
+
+// Claim leases up to limit due messages by pushing their next attempt forward.
+// SKIP LOCKED lets several relays poll the same table without picking the same
+// rows, and a relay that dies mid-batch only delays its messages by the lease.
+// Only the oldest unsent message of each aggregate is claimed, so a failed or
+// leased message holds back the next ones of its aggregate.
+func (r *GormOutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*outbox.Message, error) {
+	now := time.Now().UTC()
+	var entities []*OutboxMessages
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE "hex-api-go".outbox_messages SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM "hex-api-go".outbox_messages m
+			WHERE sent_at IS NULL AND deleted_at IS NULL AND next_attempt_at <= ?
+			AND NOT EXISTS (
+				SELECT 1 FROM "hex-api-go".outbox_messages older
+				WHERE older.aggregate_id = m.aggregate_id AND older.id < m.id
+				AND older.sent_at IS NULL AND older.deleted_at IS NULL
+			)
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, limit,
+	).Scan(&entities).Error
+
+	if err != nil {
+		return nil, postgres.TranslateError("claim outbox messages", err)
+	}
+
+	// RETURNING does not keep the subquery order
+	slices.SortFunc(entities, func(a, b *OutboxMessages) int {
+		return int(a.ID) - int(b.ID)
+	})
+
+	messages := make([]*outbox.Message, 0, len(entities))
+	for _, entity := range entities {
+		messages = append(messages, outboxMessageToRelay(entity))
+	}
+	return messages, nil
+}
+
+func (r *GormOutboxRepository) MarkSent(ctx context.Context, message *outbox.Message) error {
+	_, err := gorm.G[OutboxMessages](r.db).Where("id = ?", message.ID).Update(ctx, "sent_at", time.Now().UTC())
+	if err != nil {
+		return ddgo.NewInternalError(fmt.Sprintf("Error to mark outbox message %s as sent: %s", message.Uuid, err.Error()))
+	}
+
+	return nil
+}
+
+func (r *GormOutboxRepository) MarkFailed(ctx context.Context, message *outbox.Message) error {
+	_, err := gorm.G[OutboxMessages](r.db).Where("id = ?", message.ID).Updates(ctx, OutboxMessages{
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+	})
+	if err != nil {
+		return ddgo.NewInternalError(fmt.Sprintf("Error to reschedule outbox message %s: %s", message.Uuid, err.Error()))
+	}
+
+	return nil
+}
+
+func (r *GormOutboxRepository) DeleteSent(ctx context.Context, sentBefore time.Time, limit int) (int, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		DELETE FROM "hex-api-go".outbox_messages
+		WHERE id IN (
+			SELECT id FROM "hex-api-go".outbox_messages
+			WHERE sent_at < ?
+			LIMIT ?
+		)`,
+		sentBefore, limit,
+	)
+
+	if result.Error != nil {
+		return 0, postgres.TranslateError("delete sent outbox messages", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// saveOutboxMessages writes the aggregate events through tx, so they are
+// committed or rolled back together with the aggregate itself.
+func saveOutboxMessages(ctx context.Context, tx *gorm.DB, aggregateId string, events map[string]ddgo.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages, err := outboxMessagesToDatabase(aggregateId, events)
+	if err != nil {
+		return err
+	}
+
+	err = gorm.G[OutboxMessages](tx).CreateInBatches(ctx, &messages, len(messages))
+	if err != nil {
+		return postgres.TranslateError("write outbox messages", err)
+	}
+
+	return nil
+}
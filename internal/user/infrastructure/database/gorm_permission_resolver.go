@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"slices"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+)
+
+type GormPermissionResolver struct {
+	db *gorm.DB
+}
+
+type permissionRow struct {
+	GroupName string
+	Resource  *string
+	Action    *string
+}
+
+func NewGormPermissionResolver(db *gorm.DB) *GormPermissionResolver {
+	return &GormPermissionResolver{db: db}
+}
+
+// Resolve refuses users that are deleted or not active, so their access
+// tokens stop working as soon as the guard cache is invalidated.
+func (r *GormPermissionResolver) Resolve(ctx context.Context, userId string) (*http.ResolvedPermissions, error) {
+	var status string
+	err := r.db.WithContext(ctx).
+		Model(&Users{}).
+		Select("status").
+		Where("uuid = ?", userId).
+		Scan(&status).Error
+
+	if err != nil {
+		return nil, postgres.TranslateError("find user status", err)
+	}
+
+	if status != string(domain.UserStatusActive) {
+		return nil, apperror.NewUnauthorizedError("user account is inactive")
+	}
+
+	var rows []permissionRow
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT g.name AS group_name, a.name AS resource, p.action AS action
+		FROM "hex-api-go".users u
+		JOIN "hex-api-go".user_group_users ug ON ug.user_id = u.id AND ug.deleted_at IS NULL
+		JOIN "hex-api-go".users_groups g ON g.id = ug.user_group_id AND g.deleted_at IS NULL
+		LEFT JOIN "hex-api-go".user_groups_permissions p ON p.user_group_id = g.id AND p.deleted_at IS NULL
+		LEFT JOIN "hex-api-go".api_route_applications a ON a.id = p.api_route_application_id AND a.deleted_at IS NULL
+		WHERE u.uuid = ? AND u.deleted_at IS NULL`, userId).
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, postgres.TranslateError("resolve permissions", err)
+	}
+
+	resolved := &http.ResolvedPermissions{
+		Groups:      make([]string, 0, len(rows)),
+		Permissions: make([]http.Permission, 0, len(rows)),
+	}
+	for _, row := range rows {
+		if !slices.Contains(resolved.Groups, row.GroupName) {
+			resolved.Groups = append(resolved.Groups, row.GroupName)
+		}
+
+		if row.Resource != nil && row.Action != nil {
+			resolved.Permissions = append(resolved.Permissions, http.Permission{
+				Resource: *row.Resource,
+				Action:   *row.Action,
+			})
+		}
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormPersonRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPersonRepository(db *gorm.DB) *GormPersonRepository {
+	return &GormPersonRepository{db: db}
+}
+
+func (r *GormPersonRepository) FindByUuid(ctx context.Context, uuid string) (*domain.Person, error) {
+	entity, err := gorm.G[Person](postgres.Conn(ctx, r.db)).
+		Preload("Contacts", nil).
+		Preload("Contacts.ContactType", nil).
+		Where("uuid = ?", uuid).
+		First(ctx)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ddgo.NewNotFoundError(fmt.Sprintf("person %s not found", uuid))
+	}
+
+	if err != nil {
+		return nil, postgres.TranslateError("find person", err)
+	}
+
+	person, err := personToDomain(&entity)
+	if err != nil {
+		return nil, ddgo.NewInternalError(fmt.Sprintf("Error to rebuild person %s: %s", uuid, err.Error()))
+	}
+
+	return person, nil
+}
+
+func (r *GormPersonRepository) Update(ctx context.Context, person *domain.Person) error {
+	tx := postgres.Begin(ctx, r.db)
+	err := updatePerson(ctx, tx, person)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+// updatePerson saves the person details and contacts when the stored version
+// is still the one the person was loaded with, bumping it.
+func updatePerson(ctx context.Context, tx *gorm.DB, person *domain.Person) error {
+	entity, err := gorm.G[Person](tx).Select("id").Where("uuid = ?", person.Uuid()).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ddgo.NewNotFoundError(fmt.Sprintf("person %s not found", person.Uuid()))
+	}
+
+	if err != nil {
+		return postgres.TranslateError("find person", err)
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&Person{}).
+		Where("id = ? AND version = ?", entity.ID, person.Version()).
+		Updates(map[string]any{
+			"name":       person.Name(),
+			"birth_date": person.BirthDate().Time(),
+			"version":    gorm.Expr("version + 1"),
+		})
+
+	if result.Error != nil {
+		return postgres.TranslateError("update person", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return apperror.NewConflictError(fmt.Sprintf("person %s was changed by someone else, reload it and try again", person.Uuid()))
+	}
+
+	return saveContacts(ctx, tx, entity.ID, person.Contacts())
+}
+
+// saveContacts makes the stored contacts of the person match contacts:
+// removed ones are deleted and the others upserted by uuid. The main flags
+// are cleared first so idx_person_contacts_main never sees two main rows.
+func saveContacts(ctx context.Context, tx *gorm.DB, personId uint, contacts []*domain.Contact) error {
+	contactTypes, err := resolveContactTypes(ctx, tx, contacts)
+	if err != nil {
+		return err
+	}
+
+	uuids := make([]string, 0, len(contacts))
+	for _, contact := range contacts {
+		uuids = append(uuids, contact.Uuid())
+	}
+
+	removed := tx.WithContext(ctx).Unscoped().Where("person_id = ?", personId)
+	if len(uuids) > 0 {
+		removed = removed.Where("uuid NOT IN ?", uuids)
+	}
+	if err := removed.Delete(&PersonContacts{}).Error; err != nil {
+		return postgres.TranslateError("delete person contacts", err)
+	}
+
+	_, err = gorm.G[PersonContacts](tx).
+		Where("person_id = ? AND main", personId).
+		Update(ctx, "main", false)
+	if err != nil {
+		return postgres.TranslateError("update person contacts", err)
+	}
+
+	for _, entity := range contactsToDatabase(contacts, contactTypes) {
+		entity.PersonId = personId
+		err := tx.WithContext(ctx).
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "uuid"}},
+				DoUpdates: clause.AssignmentColumns([]string{"contact", "main", "verified_at", "person_contact_type_id", "updated_at"}),
+			}).
+			Create(&entity).Error
+		if err != nil {
+			return postgres.TranslateError("save person contact", err)
+		}
+	}
+
+	return nil
+}
+
+// resolveContactTypes maps the contact types in use to their
+// person_contacts_types ids, the rows are seeded by the migrations.
+func resolveContactTypes(ctx context.Context, tx *gorm.DB, contacts []*domain.Contact) (map[domain.ContactType]uint, error) {
+	contactTypes := map[domain.ContactType]uint{}
+	if len(contacts) == 0 {
+		return contactTypes, nil
+	}
+
+	rows, err := gorm.G[PersonContactsType](tx).Find(ctx)
+	if err != nil {
+		return nil, postgres.TranslateError("find contact types", err)
+	}
+
+	for _, row := range rows {
+		contactTypes[domain.ContactType(row.Name)] = row.ID
+	}
+
+	for _, contact := range contacts {
+		if _, ok := contactTypes[contact.ContactType()]; !ok {
+			return nil, ddgo.NewInternalError(fmt.Sprintf("contact type %s is not registered in person_contacts_types", contact.ContactType()))
+		}
+	}
+
+	return contactTypes, nil
+}
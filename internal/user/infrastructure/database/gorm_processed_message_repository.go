@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormProcessedMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewGormProcessedMessageRepository(db *gorm.DB) *GormProcessedMessageRepository {
+	return &GormProcessedMessageRepository{db: db}
+}
+
+// Claim records the event first and runs fn in the same transaction. A
+// concurrent delivery of the same event waits on the unique (consumer,
+// event_id) index and finds the row once the first one commits, while a
+// failed fn rolls the claim back so the event can be handled again.
+func (r *GormProcessedMessageRepository) Claim(
+	ctx context.Context,
+	consumer string,
+	eventId string,
+	fn func(ctx context.Context) error,
+) (bool, error) {
+	claimed := false
+	err := postgres.Transaction(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&ProcessedMessages{
+			Consumer:    consumer,
+			EventId:     eventId,
+			ProcessedAt: time.Now(),
+		})
+
+		if result.Error != nil {
+			return postgres.TranslateError("claim message", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		claimed = true
+		return fn(ctx)
+	})
+
+	return claimed, err
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRefreshTokenRepository(db *gorm.DB) *GormRefreshTokenRepository {
+	return &GormRefreshTokenRepository{db: db}
+}
+
+func (r *GormRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	tx := postgres.Begin(ctx, r.db)
+	device, err := r.findDevice(ctx, tx, token.UserId(), token.DeviceId())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = gorm.G[UserRefreshTokens](tx).Create(ctx, refreshTokenToDatabase(token, device.ID))
+	if err != nil {
+		tx.Rollback()
+		return postgres.TranslateError("create refresh token", err)
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+func (r *GormRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	entity, err := gorm.G[UserRefreshTokens](postgres.Conn(ctx, r.db)).
+		Preload("UserDevice", nil).
+		Preload("UserDevice.User", nil).
+		Where("token_hash = ?", tokenHash).
+		First(ctx)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ddgo.NewNotFoundError("refresh token not found")
+	}
+
+	if err != nil {
+		return nil, postgres.TranslateError("find refresh token", err)
+	}
+
+	token, err := refreshTokenToDomain(&entity)
+	if err != nil {
+		return nil, ddgo.NewInternalError(fmt.Sprintf("Error to rebuild refresh token %s: %s", entity.Uuid, err.Error()))
+	}
+
+	return token, nil
+}
+
+// Rotate only marks the current token when nobody rotated it before, so two
+// concurrent refreshes with the same token cannot both succeed. The device is
+// locked first, so a revocation either lands before and fails the rotation or
+// waits and revokes the new token too.
+func (r *GormRefreshTokenRepository) Rotate(ctx context.Context, current *domain.RefreshToken, next *domain.RefreshToken) error {
+	tx := postgres.Begin(ctx, r.db)
+	device, err := r.findDevice(ctx, tx, next.UserId(), next.DeviceId())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := gorm.G[UserRefreshTokens](tx).
+		Where("uuid = ? AND rotated_at IS NULL AND revoked_at IS NULL", current.Uuid()).
+		Update(ctx, "rotated_at", current.RotatedAt())
+
+	if err != nil {
+		tx.Rollback()
+		return postgres.TranslateError("rotate refresh token", err)
+	}
+
+	if rows == 0 {
+		tx.Rollback()
+		return ddgo.NewAlreadyExistsError("refresh token already rotated")
+	}
+
+	err = gorm.G[UserRefreshTokens](tx).Create(ctx, refreshTokenToDatabase(next, device.ID))
+	if err != nil {
+		tx.Rollback()
+		return postgres.TranslateError("create refresh token", err)
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+func (r *GormRefreshTokenRepository) RevokeFamily(ctx context.Context, familyId string) error {
+	_, err := gorm.G[UserRefreshTokens](postgres.Conn(ctx, r.db)).
+		Where("family_id = ? AND revoked_at IS NULL", familyId).
+		Update(ctx, "revoked_at", time.Now())
+
+	if err != nil {
+		return postgres.TranslateError("revoke refresh token family", err)
+	}
+
+	return nil
+}
+
+func (r *GormRefreshTokenRepository) RevokeByUser(ctx context.Context, userId string) error {
+	_, err := gorm.G[UserRefreshTokens](postgres.Conn(ctx, r.db)).
+		Where(`revoked_at IS NULL AND user_device_id IN (
+			SELECT d.id
+			FROM "hex-api-go".users_devices d
+			JOIN "hex-api-go".users u ON u.id = d.user_id
+			WHERE u.uuid = ?)`, userId).
+		Update(ctx, "revoked_at", time.Now())
+
+	if err != nil {
+		return postgres.TranslateError("revoke user refresh tokens", err)
+	}
+
+	return nil
+}
+
+func (r *GormRefreshTokenRepository) RevokeByDevice(ctx context.Context, userId string, deviceId string) error {
+	_, err := gorm.G[UserRefreshTokens](postgres.Conn(ctx, r.db)).
+		Where(`revoked_at IS NULL AND user_device_id IN (
+			SELECT d.id
+			FROM "hex-api-go".users_devices d
+			JOIN "hex-api-go".users u ON u.id = d.user_id
+			WHERE u.uuid = ? AND d.device_id = ?)`, userId, deviceId).
+		Update(ctx, "revoked_at", time.Now())
+
+	if err != nil {
+		return postgres.TranslateError("revoke device refresh tokens", err)
+	}
+
+	return nil
+}
+
+// findDevice locks the active device the token belongs to until the end of
+// tx, devices are registered through the user aggregate on login.
+func (r *GormRefreshTokenRepository) findDevice(ctx context.Context, tx *gorm.DB, userId string, deviceId string) (*UsersDevice, error) {
+	device, err := gorm.G[UsersDevice](tx, clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where(`device_id = ? AND revoked_at IS NULL AND user_id = (SELECT id FROM "hex-api-go".users WHERE uuid = ?)`, deviceId, userId).
+		First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ddgo.NewNotFoundError(fmt.Sprintf("device %s of user %s not registered or revoked", deviceId, userId))
+	}
+
+	if err != nil {
+		return nil, postgres.TranslateError("find device", err)
+	}
+
+	return &device, nil
+}
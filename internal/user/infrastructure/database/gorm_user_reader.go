@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listusers"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+)
+
+// GormUserReader serves the user listing straight from the tables, without
+// rebuilding aggregates.
+type GormUserReader struct {
+	db *gorm.DB
+}
+
+type userListRow struct {
+	Uuid       string
+	Username   string
+	Status     string
+	PersonUuid string
+	PersonName string
+	Document   string
+	CreatedAt  time.Time
+}
+
+var userSortColumns = map[listusers.SortKey]string{
+	listusers.SortByCreatedAt: "u.created_at",
+	listusers.SortByUsername:  "u.username",
+}
+
+func NewGormUserReader(db *gorm.DB) *GormUserReader {
+	return &GormUserReader{db: db}
+}
+
+func (r *GormUserReader) List(ctx context.Context, filter *listusers.Filter) ([]*listusers.Item, int64, error) {
+	var total int64
+	err := r.filtered(ctx, filter).Count(&total).Error
+	if err != nil {
+		return nil, 0, postgres.TranslateError("count users", err)
+	}
+
+	column := userSortColumns[filter.Sort]
+	direction := "ASC"
+	comparison := ">"
+	if filter.Descending {
+		direction = "DESC"
+		comparison = "<"
+	}
+
+	query := r.filtered(ctx, filter).
+		Select("u.uuid, u.username, u.status, p.uuid AS person_uuid, p.name AS person_name, p.document, u.created_at").
+		Order(fmt.Sprintf("%s %s, u.uuid %s", column, direction, direction)).
+		Limit(filter.Limit)
+
+	if filter.After != nil {
+		value, err := cursorValue(filter.Sort, filter.After.Value)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where(fmt.Sprintf("(%s, u.uuid) %s (?, ?)", column, comparison), value, filter.After.Id)
+	} else if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var rows []userListRow
+	err = query.Scan(&rows).Error
+	if err != nil {
+		return nil, 0, postgres.TranslateError("list users", err)
+	}
+
+	items := make([]*listusers.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, &listusers.Item{
+			Id:         row.Uuid,
+			Username:   row.Username,
+			Status:     row.Status,
+			PersonId:   row.PersonUuid,
+			PersonName: row.PersonName,
+			Document:   row.Document,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+
+	return items, total, nil
+}
+
+func (r *GormUserReader) filtered(ctx context.Context, filter *listusers.Filter) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Table(`"hex-api-go".users u`).
+		Joins(`JOIN "hex-api-go".persons p ON p.id = u.person_id`).
+		Where("u.deleted_at IS NULL")
+
+	if filter.UsernamePrefix != "" {
+		query = query.Where("u.username LIKE ?", likeEscaper.Replace(filter.UsernamePrefix)+"%")
+	}
+
+	if filter.Document != "" {
+		query = query.Where("p.document = ?", filter.Document)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("u.status = ?", filter.Status)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("u.created_at >= ?", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("u.created_at <= ?", *filter.CreatedTo)
+	}
+
+	if filter.GroupId != "" {
+		query = query.Where(`EXISTS (
+			SELECT 1
+			FROM "hex-api-go".user_group_users ug
+			JOIN "hex-api-go".users_groups g ON g.id = ug.user_group_id AND g.deleted_at IS NULL
+			WHERE ug.user_id = u.id AND ug.deleted_at IS NULL AND g.uuid = ?)`, filter.GroupId)
+	}
+
+	return query
+}
+
+func cursorValue(key listusers.SortKey, value string) (any, error) {
+	if key != listusers.SortByCreatedAt {
+		return value, nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, ddgo.NewInvalidDataError(`{"Cursor":{"IsValid":false,"FailedValidators":["cursor"]}}`)
+	}
+	return createdAt, nil
+}
+
+// likeEscaper keeps a username prefix from carrying LIKE wildcards.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
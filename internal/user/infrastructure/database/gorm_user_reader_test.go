@@ -0,0 +1,21 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listusers"
+)
+
+func TestCursorValue(t *testing.T) {
+	t.Run("Should return a validation error for a malformed creation time", func(t *testing.T) {
+		t.Parallel()
+		_, err := cursorValue(listusers.SortByCreatedAt, "yesterday")
+
+		var invalid *ddgo.InvalidDataError
+		if !errors.As(err, &invalid) {
+			t.Errorf("Should return InvalidDataError, got: %v", err)
+		}
+	})
+}
@@ -2,13 +2,17 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
-	"os"
+	"maps"
+	"time"
 
 	"github.com/jeffersonbrasilino/ddgo"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormUserRepository struct {
@@ -16,33 +20,270 @@ type GormUserRepository struct {
 }
 
 func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
+	return &GormUserRepository{db: db}
+}
+
+func (r *GormUserRepository) Create(ctx context.Context, user *domain.User) error {
+	tx := postgres.Begin(ctx, r.db)
+	contactTypes, err := resolveContactTypes(ctx, tx, user.Person().Contacts())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	entity := toDatabase(user, contactTypes)
+	result := gorm.WithResult()
+	err = gorm.G[Users](tx, result).Create(ctx, entity)
+	if err != nil {
+		tx.Rollback()
+		return postgres.TranslateError("create user", err)
+	}
+
+	err = saveOutboxMessages(ctx, tx, user.Uuid(), user.DomainEvents())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+// Update saves the user and its person when both still have the versions
+// they were loaded with, together with the recorded events.
+func (r *GormUserRepository) Update(ctx context.Context, user *domain.User) error {
+	changes := map[string]any{
+		"username": user.Username(),
+		"password": user.Password().Hash(),
+		"status":   string(user.Status()),
+		"version":  gorm.Expr("version + 1"),
+	}
+	if user.DeletedAt() != nil {
+		changes["deleted_at"] = *user.DeletedAt()
+	}
+	maps.Copy(changes, verificationToDatabase(user.Verification()))
+	maps.Copy(changes, passwordResetToDatabase(user.PasswordReset()))
+
+	tx := postgres.Begin(ctx, r.db)
+	result := tx.WithContext(ctx).
+		Model(&Users{}).
+		Where("uuid = ? AND version = ?", user.Uuid(), user.Version()).
+		Updates(changes)
+
+	if result.Error != nil {
+		tx.Rollback()
+		return postgres.TranslateError("update user", result.Error)
+	}
 
-	if os.Getenv("GORM_AUTO_MIGRATE") == "1" {
-		db.SetupJoinTable(&Users{}, "UserGroups", &UserGroupUser{})
-		err := db.AutoMigrate(&Users{}, &Person{}, &UsersGroups{}, &PersonContacts{}, &PersonContactsType{}, &UserGroupsPermissions{}, &UsersDevice{})
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return r.staleOrMissing(ctx, user.Uuid())
+	}
+
+	err := updatePerson(ctx, tx, user.Person())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if user.DeletedAt() != nil {
+		err = removeGroupMemberships(ctx, tx, user.Uuid())
 		if err != nil {
-			slog.Error("[GormUserRepository]", "error", err)
+			tx.Rollback()
+			return err
 		}
 	}
 
-	if os.Getenv("APP_ENV") == "local" {
-		db = db.Debug()
+	err = saveOutboxMessages(ctx, tx, user.Uuid(), user.DomainEvents())
+	if err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	return &GormUserRepository{db: db}
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
 }
 
-func (r *GormUserRepository) Create(ctx context.Context, user *domain.User) error {
-	tx := r.db.Begin()
-	entity := toDatabase(user)
-	result := gorm.WithResult()
-	err := gorm.G[Users](tx, result).Create(ctx, entity)
+// removeGroupMemberships takes a deleted user out of every group, a group
+// must not keep members that can no longer be loaded.
+func removeGroupMemberships(ctx context.Context, tx *gorm.DB, uuid string) error {
+	err := tx.WithContext(ctx).
+		Unscoped().
+		Where(`user_id IN (SELECT id FROM "hex-api-go".users WHERE uuid = ?)`, uuid).
+		Delete(&UserGroupUser{}).Error
+	if err != nil {
+		return postgres.TranslateError("remove group memberships", err)
+	}
+
+	return nil
+}
+
+// SaveDevices saves the devices changed since the user was loaded with the
+// recorded events. It does not check nor bump the user version, logins must
+// not make profile edits stale.
+func (r *GormUserRepository) SaveDevices(ctx context.Context, user *domain.User) error {
+	tx := postgres.Begin(ctx, r.db)
+	entity, err := gorm.G[Users](tx).Select("id").Where("uuid = ?", user.Uuid()).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return ddgo.NewNotFoundError(fmt.Sprintf("user %s not found", user.Uuid()))
+	}
+
 	if err != nil {
 		tx.Rollback()
-		return ddgo.NewInternalError(fmt.Sprintf("Error to create user: %s", err.Error()))
+		return postgres.TranslateError("find user", err)
+	}
+
+	for _, device := range devicesToDatabase(user.ChangedDevices(), entity.ID) {
+		err := tx.WithContext(ctx).
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "device_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"user_agent", "last_ip", "last_seen_at", "revoked_at", "updated_at", "deleted_at"}),
+			}).
+			Create(&device).Error
+		if err != nil {
+			tx.Rollback()
+			return postgres.TranslateError("save user device", err)
+		}
 	}
 
-	fmt.Println("[REPOSITORY RESULT]", result)
+	err = saveOutboxMessages(ctx, tx, user.Uuid(), user.DomainEvents())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return postgres.TranslateError("commit transaction", tx.Commit().Error)
+}
+
+func (r *GormUserRepository) staleOrMissing(ctx context.Context, uuid string) error {
+	count, err := gorm.G[Users](postgres.Conn(ctx, r.db)).Where("uuid = ?", uuid).Count(ctx, "id")
+	if err != nil {
+		return postgres.TranslateError("find user", err)
+	}
+
+	if count == 0 {
+		return ddgo.NewNotFoundError(fmt.Sprintf("user %s not found", uuid))
+	}
+
+	return apperror.NewConflictError(fmt.Sprintf("user %s was changed by someone else, reload it and try again", uuid))
+}
+
+func (r *GormUserRepository) FindByUuid(ctx context.Context, uuid string) (*domain.User, error) {
+	return r.findOne(ctx, fmt.Sprintf("user %s not found", uuid), "uuid = ?", uuid)
+}
+
+func (r *GormUserRepository) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.findOne(ctx, fmt.Sprintf("user %s not found", username), "username = ?", username)
+}
+
+// FindByEmail looks the user up by an email contact of its person.
+func (r *GormUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(ctx, fmt.Sprintf("user with email %s not found", email),
+		`person_id IN (SELECT c.person_id FROM "hex-api-go".person_contacts c
+			JOIN "hex-api-go".person_contacts_types t ON t.id = c.person_contact_type_id
+			WHERE t.name = ? AND c.contact = ? AND c.deleted_at IS NULL)`,
+		string(domain.ContactTypeEmail), email)
+}
+
+func (r *GormUserRepository) FindByPasswordResetHash(ctx context.Context, hash string) (*domain.User, error) {
+	return r.findOne(ctx, "password reset not found", "password_reset_hash = ?", hash)
+}
+
+func (r *GormUserRepository) findOne(ctx context.Context, notFoundMessage string, query string, args ...any) (*domain.User, error) {
+	entity, err := gorm.G[Users](postgres.Conn(ctx, r.db)).
+		Preload("Person", nil).
+		Preload("Person.Contacts", nil).
+		Preload("Person.Contacts.ContactType", nil).
+		Preload("Devices", nil).
+		Where(query, args...).
+		First(ctx)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ddgo.NewNotFoundError(notFoundMessage)
+	}
+
+	if err != nil {
+		return nil, postgres.TranslateError("find user", err)
+	}
+
+	user, err := toDomain(&entity)
+	if err != nil {
+		return nil, ddgo.NewInternalError(fmt.Sprintf("Error to rebuild user %s: %s", entity.Uuid, err.Error()))
+	}
+
+	return user, nil
+}
+
+// AnonymizeDeleted erases the personal data of up to limit users deleted
+// before deletedBefore and returns how many were anonymized. Persons still
+// linked to a user that is not deleted are left untouched. The usernames kept
+// in the outbox payloads of the user events are replaced as well, and group
+// memberships left behind by older deletions are removed.
+func (r *GormUserRepository) AnonymizeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	tx := postgres.Begin(ctx, r.db)
+	var ids []uint
+	err := tx.WithContext(ctx).
+		Unscoped().
+		Model(&Users{}).
+		Where("deleted_at < ? AND anonymized_at IS NULL", deletedBefore).
+		Order("deleted_at").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Pluck("id", &ids).Error
+
+	if err != nil {
+		tx.Rollback()
+		return 0, postgres.TranslateError("find deleted users", err)
+	}
+
+	if len(ids) == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	statements := []struct {
+		operation string
+		sql       string
+	}{
+		{"anonymize contacts", `DELETE FROM "hex-api-go".person_contacts c
+			USING "hex-api-go".users u
+			WHERE c.person_id = u.person_id AND u.id IN ?
+			AND NOT EXISTS (SELECT 1 FROM "hex-api-go".users o WHERE o.person_id = u.person_id AND o.deleted_at IS NULL)`},
+		{"anonymize persons", `UPDATE "hex-api-go".persons p
+			SET name = 'anonymized', document = 'anonymized-' || p.uuid, birth_date = DATE '1900-01-01',
+				deleted_at = COALESCE(p.deleted_at, now()), updated_at = now()
+			FROM "hex-api-go".users u
+			WHERE p.id = u.person_id AND u.id IN ?
+			AND NOT EXISTS (SELECT 1 FROM "hex-api-go".users o WHERE o.person_id = p.id AND o.deleted_at IS NULL)`},
+		{"remove group memberships", `DELETE FROM "hex-api-go".user_group_users WHERE user_id IN ?`},
+		{"anonymize devices", `UPDATE "hex-api-go".users_devices SET user_agent = '', last_ip = '', updated_at = now()
+			WHERE user_id IN ?`},
+		{"anonymize outbox messages", `UPDATE "hex-api-go".outbox_messages o
+			SET payload = jsonb_set(jsonb_set(o.payload,
+					'{username}', to_jsonb('deleted-' || u.uuid), false),
+					'{previousUsername}', to_jsonb('deleted-' || u.uuid), false),
+				updated_at = now()
+			FROM "hex-api-go".users u
+			WHERE o.aggregate_id = u.uuid AND u.id IN ?
+			AND jsonb_exists_any(o.payload, array['username', 'previousUsername'])`},
+		{"anonymize users", `UPDATE "hex-api-go".users
+			SET username = 'deleted-' || uuid, password = '', verification_code = NULL, verification_contact_id = NULL,
+				password_reset_hash = NULL, password_reset_expires_at = NULL, anonymized_at = now(), updated_at = now()
+			WHERE id IN ?`},
+	}
+
+	for _, statement := range statements {
+		err = tx.WithContext(ctx).Exec(statement.sql, ids).Error
+		if err != nil {
+			tx.Rollback()
+			return 0, postgres.TranslateError(statement.operation, err)
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return 0, postgres.TranslateError("commit transaction", err)
+	}
 
-	return tx.Commit().Error
+	return len(ids), nil
 }
@@ -0,0 +1,49 @@
+package database
+
+import "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+
+func groupToDomain(group *UsersGroups, permissions []UserGroupsPermissions, members []UserGroupUser) (*domain.Group, error) {
+	groupPermissions := make([]*domain.GroupPermission, 0, len(permissions))
+	for _, permission := range permissions {
+		groupPermission, err := domain.NewGroupPermission(&domain.GroupPermissionProps{
+			Resource: permission.ApiRouteApplication.Name,
+			Action:   permission.Action,
+		})
+		if err != nil {
+			return nil, err
+		}
+		groupPermissions = append(groupPermissions, groupPermission)
+	}
+
+	groupMembers := make([]*domain.GroupMember, 0, len(members))
+	for _, member := range members {
+		// the preload leaves User empty when the user was soft deleted, its
+		// membership is gone even if the row was not removed yet.
+		if member.User.ID == 0 {
+			continue
+		}
+
+		groupMember, err := domain.NewGroupMember(&domain.GroupMemberProps{
+			UserId: member.User.Uuid,
+			Main:   member.Main,
+		})
+		if err != nil {
+			return nil, err
+		}
+		groupMembers = append(groupMembers, groupMember)
+	}
+
+	return domain.NewGroup(&domain.GroupProps{
+		UuId:        group.Uuid,
+		Name:        group.Name,
+		Permissions: groupPermissions,
+		Members:     groupMembers,
+	})
+}
+
+func groupToDatabase(group *domain.Group) *UsersGroups {
+	return &UsersGroups{
+		Uuid: group.Uuid(),
+		Name: group.Name(),
+	}
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestGroupToDomain(t *testing.T) {
+	t.Run("Should load the group after one of its members was deleted", func(t *testing.T) {
+		t.Parallel()
+		group := &UsersGroups{Uuid: "0b7c9a4e-2f1d-4f7e-9a51-7c4f0d8e1a22", Name: "admins"}
+		members := []UserGroupUser{
+			{UsersID: 1, UsersGroupsID: 1, Main: true, User: Users{Model: gorm.Model{ID: 1}, Uuid: "5f0c2e1a-8b3d-4c6e-9f7a-1d2b3c4e5f60"}},
+			// the preload leaves User empty for the soft deleted user
+			{UsersID: 2, UsersGroupsID: 1},
+		}
+
+		loaded, err := groupToDomain(group, nil, members)
+		if err != nil {
+			t.Fatalf("Should load the group, got: %v", err)
+		}
+
+		if ids := loaded.MemberIds(); !slices.Equal(ids, []string{"5f0c2e1a-8b3d-4c6e-9f7a-1d2b3c4e5f60"}) {
+			t.Errorf("Should keep only the remaining member, got: %v", ids)
+		}
+
+		if err := loaded.AddMember("7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"); err != nil {
+			t.Errorf("Should still change the group, got: %v", err)
+		}
+	})
+}
@@ -1,19 +1,228 @@
 package database
 
-import "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+import (
+	"time"
 
-func toDomain(user *Users) *domain.User {
-	return &domain.User{}
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+func toDomain(user *Users) (*domain.User, error) {
+	var deletedAt *time.Time
+	if user.DeletedAt.Valid {
+		deletedAt = &user.DeletedAt.Time
+	}
+
+	return domain.NewBuilder().
+		WithUuId(user.Uuid).
+		WithUsername(user.Username).
+		WithVersion(user.Version).
+		WithStatus(domain.UserStatus(user.Status), deletedAt).
+		WithPassword(user.Password).
+		WithVerification(verificationToDomain(user)).
+		WithPasswordReset(passwordResetToDomain(user)).
+		WithDevices(devicesToDomain(user.Devices)).
+		WithPerson(personPropsToDomain(&user.Person)).
+		Build()
+}
+
+func personToDomain(person *Person) (*domain.Person, error) {
+	props := personPropsToDomain(person)
+	document, err := domain.NewDocument(props.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	birthDate, err := domain.NewBirthDate(props.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*domain.Contact, 0, len(props.Contacts))
+	for _, contactProps := range props.Contacts {
+		contact, err := domain.NewContact(contactProps)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	props.Person.Document = document
+	props.Person.BirthDate = birthDate
+	props.Person.Contacts = contacts
+	return domain.NewPerson(props.Person)
+}
+
+func personPropsToDomain(person *Person) *domain.WithPersonProps {
+	contacts := make([]*domain.ContactProps, 0, len(person.Contacts))
+	for _, contact := range person.Contacts {
+		contacts = append(contacts, &domain.ContactProps{
+			UuId:        contact.Uuid,
+			Description: contact.Contact,
+			ContactType: contact.ContactType.Name,
+			Main:        contact.Main,
+			VerifiedAt:  contact.VerifiedAt,
+		})
+	}
+
+	return &domain.WithPersonProps{
+		Person: &domain.PersonProps{
+			UuId:    person.Uuid,
+			Name:    person.Name,
+			Version: person.Version,
+		},
+		Document: &domain.DocumentProps{
+			Value: person.Document,
+		},
+		BirthDate: &domain.BirthDateProps{
+			Value: person.BirthDate.Format("2006-01-02"),
+		},
+		Contacts: contacts,
+	}
+}
+
+func verificationToDomain(user *Users) *domain.VerificationCodeProps {
+	if user.VerificationCode == nil || user.VerificationContactId == nil {
+		return nil
+	}
+
+	props := &domain.VerificationCodeProps{
+		Hash:      *user.VerificationCode,
+		ContactId: *user.VerificationContactId,
+		Attempts:  user.VerificationAttempts,
+	}
+	if user.VerificationExpiresAt != nil {
+		props.ExpiresAt = *user.VerificationExpiresAt
+	}
+	if user.VerificationSentAt != nil {
+		props.SentAt = *user.VerificationSentAt
+	}
+	return props
+}
+
+// verificationToDatabase returns the users columns of the pending
+// verification, all of them null when there is none.
+func verificationToDatabase(code *domain.VerificationCode) map[string]any {
+	if code == nil {
+		return map[string]any{
+			"verification_code":       nil,
+			"verification_contact_id": nil,
+			"verification_expires_at": nil,
+			"verification_sent_at":    nil,
+			"verification_attempts":   0,
+		}
+	}
+
+	return map[string]any{
+		"verification_code":       code.Hash(),
+		"verification_contact_id": code.ContactId(),
+		"verification_expires_at": code.ExpiresAt(),
+		"verification_sent_at":    code.SentAt(),
+		"verification_attempts":   code.Attempts(),
+	}
+}
+
+func passwordResetToDomain(user *Users) *domain.PasswordResetProps {
+	if user.PasswordResetHash == nil || user.PasswordResetExpiresAt == nil {
+		return nil
+	}
+
+	return &domain.PasswordResetProps{
+		Hash:      *user.PasswordResetHash,
+		ExpiresAt: *user.PasswordResetExpiresAt,
+	}
+}
+
+// passwordResetToDatabase returns the users columns of the pending password
+// reset, all of them null when there is none.
+func passwordResetToDatabase(reset *domain.PasswordReset) map[string]any {
+	if reset == nil {
+		return map[string]any{
+			"password_reset_hash":       nil,
+			"password_reset_expires_at": nil,
+		}
+	}
+
+	return map[string]any{
+		"password_reset_hash":       reset.Hash(),
+		"password_reset_expires_at": reset.ExpiresAt(),
+	}
 }
 
-func toDatabase(user *domain.User) *Users {
-	return &Users{
+// toDatabase needs the person_contacts_types ids, see resolveContactTypes.
+func toDatabase(user *domain.User, contactTypes map[domain.ContactType]uint) *Users {
+	entity := &Users{
+		Uuid:     user.Uuid(),
 		Username: user.Username(),
-		Password: user.Password(),
+		Password: user.Password().Hash(),
+		Status:   string(user.Status()),
 		Person: Person{
+			Uuid:      user.Person().Uuid(),
 			Name:      user.Person().Name(),
 			Document:  user.Person().Document().Value(),
-			BirthDate: user.Person().BirthDate(),
+			BirthDate: user.Person().BirthDate().Time(),
+			Contacts:  contactsToDatabase(user.Person().Contacts(), contactTypes),
 		},
 	}
+
+	if code := user.Verification(); code != nil {
+		hash, contactId, expiresAt, sentAt := code.Hash(), code.ContactId(), code.ExpiresAt(), code.SentAt()
+		entity.VerificationCode = &hash
+		entity.VerificationContactId = &contactId
+		entity.VerificationExpiresAt = &expiresAt
+		entity.VerificationSentAt = &sentAt
+		entity.VerificationAttempts = code.Attempts()
+	}
+
+	if reset := user.PasswordReset(); reset != nil {
+		hash, expiresAt := reset.Hash(), reset.ExpiresAt()
+		entity.PasswordResetHash = &hash
+		entity.PasswordResetExpiresAt = &expiresAt
+	}
+	return entity
+}
+
+func contactsToDatabase(contacts []*domain.Contact, contactTypes map[domain.ContactType]uint) []PersonContacts {
+	entities := make([]PersonContacts, 0, len(contacts))
+	for _, contact := range contacts {
+		entities = append(entities, PersonContacts{
+			Uuid:          contact.Uuid(),
+			Contact:       contact.Description(),
+			Main:          contact.Main(),
+			VerifiedAt:    contact.VerifiedAt(),
+			ContactTypeId: contactTypes[contact.ContactType()],
+		})
+	}
+	return entities
+}
+
+func devicesToDomain(devices []UsersDevice) []*domain.DeviceProps {
+	props := make([]*domain.DeviceProps, 0, len(devices))
+	for _, device := range devices {
+		props = append(props, &domain.DeviceProps{
+			DeviceId:     device.DeviceId,
+			UserAgent:    device.UserAgent,
+			LastIp:       device.LastIp,
+			LastSeenAt:   device.LastSeenAt,
+			RegisteredAt: device.CreatedAt,
+			RevokedAt:    device.RevokedAt,
+		})
+	}
+	return props
+}
+
+func devicesToDatabase(devices []*domain.Device, userId uint) []UsersDevice {
+	entities := make([]UsersDevice, 0, len(devices))
+	for _, device := range devices {
+		entity := UsersDevice{
+			UserId:     userId,
+			DeviceId:   device.DeviceId(),
+			UserAgent:  device.UserAgent(),
+			LastIp:     device.LastIp(),
+			LastSeenAt: device.LastSeenAt(),
+			RevokedAt:  device.RevokedAt(),
+		}
+		entity.CreatedAt = device.RegisteredAt()
+		entities = append(entities, entity)
+	}
+	return entities
 }
@@ -0,0 +1,17 @@
+package database
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/migration"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// MigrationSource exposes the user module schema migrations to cmd/migrate.
+func MigrationSource() migration.Source {
+	files, _ := fs.Sub(migrations, "migrations")
+	return migration.Source{Module: "user", FS: files}
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "hex-api-go".person_contacts;
+DROP TABLE IF EXISTS "hex-api-go".person_contacts_types;
+DROP TABLE IF EXISTS "hex-api-go".persons;
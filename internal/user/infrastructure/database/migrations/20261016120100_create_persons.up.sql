@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS "hex-api-go".persons (
+    id         bigserial   PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    uuid       uuid        NOT NULL,
+    name       text        NOT NULL,
+    document   text        NOT NULL,
+    birth_date text        NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_persons_deleted_at ON "hex-api-go".persons (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_persons_uuid ON "hex-api-go".persons (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_persons_document ON "hex-api-go".persons (document);
+
+CREATE TABLE IF NOT EXISTS "hex-api-go".person_contacts_types (
+    id         bigserial   PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name       text        NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_person_contacts_types_deleted_at ON "hex-api-go".person_contacts_types (deleted_at);
+
+CREATE TABLE IF NOT EXISTS "hex-api-go".person_contacts (
+    id                     bigserial   PRIMARY KEY,
+    created_at             timestamptz,
+    updated_at             timestamptz,
+    deleted_at             timestamptz,
+    uuid                   uuid        NOT NULL,
+    contact                text        NOT NULL,
+    main                   boolean     NOT NULL DEFAULT false,
+    person_id              bigint      NOT NULL,
+    person_contact_type_id bigint      NOT NULL,
+    CONSTRAINT fk_person_contacts_person FOREIGN KEY (person_id) REFERENCES "hex-api-go".persons (id),
+    CONSTRAINT fk_person_contacts_contact_type FOREIGN KEY (person_contact_type_id) REFERENCES "hex-api-go".person_contacts_types (id)
+);
+CREATE INDEX IF NOT EXISTS idx_person_contacts_deleted_at ON "hex-api-go".person_contacts (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_contacts_uuid ON "hex-api-go".person_contacts (uuid);
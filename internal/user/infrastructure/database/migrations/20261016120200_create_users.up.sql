@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "hex-api-go".users (
+    id                bigserial   PRIMARY KEY,
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    deleted_at        timestamptz,
+    uuid              uuid        NOT NULL,
+    username          text        NOT NULL,
+    password          text        NOT NULL,
+    verification_code text,
+    person_id         bigint      NOT NULL,
+    CONSTRAINT fk_users_person FOREIGN KEY (person_id) REFERENCES "hex-api-go".persons (id)
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON "hex-api-go".users (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_uuid ON "hex-api-go".users (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON "hex-api-go".users (username);
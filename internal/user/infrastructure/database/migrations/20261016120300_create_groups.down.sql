@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "hex-api-go".user_groups_permissions;
+DROP TABLE IF EXISTS "hex-api-go".api_route_applications;
+DROP TABLE IF EXISTS "hex-api-go".user_group_users;
+DROP TABLE IF EXISTS "hex-api-go".users_groups;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS "hex-api-go".users_groups (
+    id         bigserial   PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    uuid       uuid        NOT NULL,
+    name       text        NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_users_groups_deleted_at ON "hex-api-go".users_groups (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_groups_uuid ON "hex-api-go".users_groups (uuid);
+
+CREATE TABLE IF NOT EXISTS "hex-api-go".user_group_users (
+    created_at    timestamptz,
+    updated_at    timestamptz,
+    deleted_at    timestamptz,
+    main          boolean     NOT NULL DEFAULT false,
+    user_id       bigint      NOT NULL,
+    user_group_id bigint      NOT NULL,
+    PRIMARY KEY (user_id, user_group_id),
+    CONSTRAINT fk_user_group_users_user FOREIGN KEY (user_id) REFERENCES "hex-api-go".users (id),
+    CONSTRAINT fk_user_group_users_group FOREIGN KEY (user_group_id) REFERENCES "hex-api-go".users_groups (id)
+);
+CREATE INDEX IF NOT EXISTS idx_user_group_users_deleted_at ON "hex-api-go".user_group_users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS "hex-api-go".api_route_applications (
+    id         bigserial   PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name       text        NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_api_route_applications_deleted_at ON "hex-api-go".api_route_applications (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_route_applications_name ON "hex-api-go".api_route_applications (name);
+
+CREATE TABLE IF NOT EXISTS "hex-api-go".user_groups_permissions (
+    id                       bigserial   PRIMARY KEY,
+    created_at               timestamptz,
+    updated_at               timestamptz,
+    deleted_at               timestamptz,
+    api_route_application_id bigint      NOT NULL,
+    action                   text        NOT NULL,
+    user_group_id            bigint      NOT NULL,
+    CONSTRAINT fk_user_groups_permissions_route FOREIGN KEY (api_route_application_id) REFERENCES "hex-api-go".api_route_applications (id),
+    CONSTRAINT fk_user_groups_permissions_group FOREIGN KEY (user_group_id) REFERENCES "hex-api-go".users_groups (id)
+);
+CREATE INDEX IF NOT EXISTS idx_user_groups_permissions_deleted_at ON "hex-api-go".user_groups_permissions (deleted_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "hex-api-go".users_refresh_tokens;
+DROP TABLE IF EXISTS "hex-api-go".users_devices;
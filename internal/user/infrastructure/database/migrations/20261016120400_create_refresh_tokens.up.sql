@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS "hex-api-go".users_devices (
+    id         bigserial   PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id    bigint      NOT NULL,
+    device_id  text        NOT NULL,
+    CONSTRAINT fk_users_devices_user FOREIGN KEY (user_id) REFERENCES "hex-api-go".users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_users_devices_deleted_at ON "hex-api-go".users_devices (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_devices_user_device ON "hex-api-go".users_devices (user_id, device_id);
+
+CREATE TABLE IF NOT EXISTS "hex-api-go".users_refresh_tokens (
+    id             bigserial   PRIMARY KEY,
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    deleted_at     timestamptz,
+    uuid           uuid        NOT NULL,
+    user_device_id bigint      NOT NULL,
+    family_id      uuid        NOT NULL,
+    token_hash     text        NOT NULL,
+    expires_at     timestamptz NOT NULL,
+    rotated_at     timestamptz,
+    revoked_at     timestamptz,
+    CONSTRAINT fk_users_refresh_tokens_device FOREIGN KEY (user_device_id) REFERENCES "hex-api-go".users_devices (id)
+);
+CREATE INDEX IF NOT EXISTS idx_users_refresh_tokens_deleted_at ON "hex-api-go".users_refresh_tokens (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_refresh_tokens_uuid ON "hex-api-go".users_refresh_tokens (uuid);
+CREATE INDEX IF NOT EXISTS idx_users_refresh_tokens_family_id ON "hex-api-go".users_refresh_tokens (family_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_refresh_tokens_token_hash ON "hex-api-go".users_refresh_tokens (token_hash);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "hex-api-go".outbox_messages (
+    id              bigserial   PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    deleted_at      timestamptz,
+    uuid            uuid        NOT NULL,
+    event_name      text        NOT NULL,
+    aggregate_id    uuid        NOT NULL,
+    payload         jsonb       NOT NULL,
+    occurred_on     timestamptz NOT NULL,
+    version         bigint      NOT NULL DEFAULT 1,
+    attempts        bigint      NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error      text,
+    sent_at         timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_deleted_at ON "hex-api-go".outbox_messages (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_uuid ON "hex-api-go".outbox_messages (uuid);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_next_attempt_at ON "hex-api-go".outbox_messages (next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_sent_at ON "hex-api-go".outbox_messages (sent_at);
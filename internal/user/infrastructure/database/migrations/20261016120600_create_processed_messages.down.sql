@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "hex-api-go".processed_messages;
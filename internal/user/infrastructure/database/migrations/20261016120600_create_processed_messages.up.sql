@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "hex-api-go".processed_messages (
+    id           bigserial   PRIMARY KEY,
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    deleted_at   timestamptz,
+    consumer     text        NOT NULL,
+    event_id     text        NOT NULL,
+    processed_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_processed_messages_deleted_at ON "hex-api-go".processed_messages (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_processed_messages_consumer_event ON "hex-api-go".processed_messages (consumer, event_id);
@@ -0,0 +1,2 @@
+-- the original masks are not kept, unmasked documents stay valid
+SELECT 1;
@@ -0,0 +1,80 @@
+-- documents are stored unmasked and uppercase since Document became a CPF/CNPJ
+-- value object. Persons the value object would reject, and documents that
+-- collide once normalized, abort the migration with their ids: they must be
+-- fixed by hand before the rule goes live, otherwise every read of those
+-- users fails.
+CREATE FUNCTION pg_temp.valid_document(value text) RETURNS boolean
+LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    weights int[] := ARRAY[6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2];
+    size    int;
+    total   int;
+    digit   int;
+BEGIN
+    IF value ~ '^(.)\1*$' THEN
+        RETURN false;
+    END IF;
+
+    IF value ~ '^[0-9]{11}$' THEN
+        FOREACH size IN ARRAY ARRAY[9, 10] LOOP
+            total := 0;
+            FOR i IN 1..size LOOP
+                total := total + (ascii(substr(value, i, 1)) - 48) * (size + 2 - i);
+            END LOOP;
+            digit := total * 10 % 11 % 10;
+            IF digit <> ascii(substr(value, size + 1, 1)) - 48 THEN
+                RETURN false;
+            END IF;
+        END LOOP;
+        RETURN true;
+    END IF;
+
+    -- CNPJ, numeric or alphanumeric: every character is worth its ASCII code minus 48
+    IF value ~ '^[0-9A-Z]{12}[0-9]{2}$' THEN
+        FOREACH size IN ARRAY ARRAY[12, 13] LOOP
+            total := 0;
+            FOR i IN 1..size LOOP
+                total := total + (ascii(substr(value, i, 1)) - 48) * weights[13 - size + i];
+            END LOOP;
+            digit := CASE WHEN total % 11 >= 2 THEN 11 - total % 11 ELSE 0 END;
+            IF digit <> ascii(substr(value, size + 1, 1)) - 48 THEN
+                RETURN false;
+            END IF;
+        END LOOP;
+        RETURN true;
+    END IF;
+
+    RETURN false;
+END
+$$;
+
+DO $$
+DECLARE
+    invalid   text;
+    colliding text;
+BEGIN
+    SELECT string_agg(id::text, ', ' ORDER BY id) INTO invalid
+    FROM "hex-api-go".persons
+    WHERE deleted_at IS NULL
+      AND NOT pg_temp.valid_document(upper(regexp_replace(document, '[-./[:space:]]', '', 'g')));
+
+    SELECT string_agg(ids, '; ') INTO colliding
+    FROM (
+        SELECT string_agg(id::text, ', ' ORDER BY id) AS ids
+        FROM "hex-api-go".persons
+        GROUP BY upper(regexp_replace(document, '[-./[:space:]]', '', 'g'))
+        HAVING count(*) > 1
+    ) duplicates;
+
+    IF invalid IS NOT NULL OR colliding IS NOT NULL THEN
+        RAISE EXCEPTION 'fix the persons documents before normalizing them. Invalid CPF/CNPJ, person ids: %. Same document once normalized, person ids: %.',
+            coalesce(invalid, 'none'), coalesce(colliding, 'none');
+    END IF;
+END
+$$;
+
+UPDATE "hex-api-go".persons
+SET document = upper(regexp_replace(document, '[-./[:space:]]', '', 'g'))
+WHERE document <> upper(regexp_replace(document, '[-./[:space:]]', '', 'g'));
+
+DROP FUNCTION pg_temp.valid_document(text);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "hex-api-go".idx_person_contacts_main;
+DROP INDEX IF EXISTS "hex-api-go".idx_person_contacts_types_name;
@@ -0,0 +1,8 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_contacts_types_name ON "hex-api-go".person_contacts_types (name);
+
+INSERT INTO "hex-api-go".person_contacts_types (created_at, updated_at, name)
+VALUES (now(), now(), 'email'), (now(), now(), 'mobile'), (now(), now(), 'phone'), (now(), now(), 'whatsapp')
+ON CONFLICT (name) DO NOTHING;
+
+-- a person has at most one main contact
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_contacts_main ON "hex-api-go".person_contacts (person_id) WHERE main AND deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE "hex-api-go".persons
+    ALTER COLUMN birth_date TYPE text USING to_char(birth_date, 'YYYY-MM-DD');
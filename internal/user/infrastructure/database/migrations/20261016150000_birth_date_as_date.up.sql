@@ -0,0 +1,9 @@
+-- accepts the formats BirthDate parses, anything else fails the migration
+-- and must be fixed by hand before running it again
+ALTER TABLE "hex-api-go".persons
+    ALTER COLUMN birth_date TYPE date USING (
+        CASE
+            WHEN birth_date ~ '^\d{2}/\d{2}/\d{4}$' THEN to_date(birth_date, 'DD/MM/YYYY')
+            ELSE birth_date::date
+        END
+    );
@@ -0,0 +1,2 @@
+ALTER TABLE "hex-api-go".persons DROP COLUMN IF EXISTS version;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS version;
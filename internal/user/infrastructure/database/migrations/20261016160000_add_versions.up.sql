@@ -0,0 +1,4 @@
+-- optimistic concurrency: every update bumps the version and only applies
+-- when the row still has the version the caller loaded
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE "hex-api-go".persons ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "hex-api-go".idx_users_pending_anonymization;
+ALTER TABLE "hex-api-go".users DROP CONSTRAINT IF EXISTS chk_users_status;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS anonymized_at;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS status;
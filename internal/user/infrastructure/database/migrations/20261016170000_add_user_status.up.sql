@@ -0,0 +1,11 @@
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'active';
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS anonymized_at timestamptz;
+ALTER TABLE "hex-api-go".users DROP CONSTRAINT IF EXISTS chk_users_status;
+ALTER TABLE "hex-api-go".users ADD CONSTRAINT chk_users_status CHECK (status IN ('active', 'inactive', 'deleted'));
+
+-- users soft deleted before the status existed
+UPDATE "hex-api-go".users SET status = 'deleted' WHERE deleted_at IS NOT NULL;
+
+-- the retention job looks for deleted users not anonymized yet
+CREATE INDEX IF NOT EXISTS idx_users_pending_anonymization ON "hex-api-go".users (deleted_at)
+    WHERE deleted_at IS NOT NULL AND anonymized_at IS NULL;
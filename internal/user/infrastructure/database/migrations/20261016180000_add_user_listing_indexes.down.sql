@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "hex-api-go".idx_users_username_pattern;
+DROP INDEX IF EXISTS "hex-api-go".idx_users_created_at_uuid;
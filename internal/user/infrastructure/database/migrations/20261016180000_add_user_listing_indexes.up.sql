@@ -0,0 +1,4 @@
+-- keyset pagination of the user listing, see GormUserReader
+CREATE INDEX IF NOT EXISTS idx_users_created_at_uuid ON "hex-api-go".users (created_at, uuid) WHERE deleted_at IS NULL;
+-- username prefix filter and username sort
+CREATE INDEX IF NOT EXISTS idx_users_username_pattern ON "hex-api-go".users (username text_pattern_ops, uuid) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE "hex-api-go".person_contacts DROP COLUMN IF EXISTS verified_at;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS verification_attempts;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS verification_sent_at;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS verification_expires_at;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS verification_contact_id;
@@ -0,0 +1,10 @@
+-- pending email verification, verification_code keeps the code hash
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS verification_contact_id uuid;
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS verification_expires_at timestamptz;
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS verification_sent_at timestamptz;
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS verification_attempts integer NOT NULL DEFAULT 0;
+
+-- codes written before the flow existed cannot be checked
+UPDATE "hex-api-go".users SET verification_code = NULL WHERE verification_contact_id IS NULL;
+
+ALTER TABLE "hex-api-go".person_contacts ADD COLUMN IF NOT EXISTS verified_at timestamptz;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "hex-api-go".idx_users_password_reset_hash;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS password_reset_expires_at;
+ALTER TABLE "hex-api-go".users DROP COLUMN IF EXISTS password_reset_hash;
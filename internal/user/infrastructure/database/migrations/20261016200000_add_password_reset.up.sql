@@ -0,0 +1,7 @@
+-- pending password reset, password_reset_hash keeps the token hash
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS password_reset_hash varchar(64);
+ALTER TABLE "hex-api-go".users ADD COLUMN IF NOT EXISTS password_reset_expires_at timestamptz;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_password_reset_hash
+    ON "hex-api-go".users (password_reset_hash)
+    WHERE password_reset_hash IS NOT NULL;
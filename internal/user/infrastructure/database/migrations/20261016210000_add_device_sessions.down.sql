@@ -0,0 +1,4 @@
+ALTER TABLE "hex-api-go".users_devices DROP COLUMN IF EXISTS revoked_at;
+ALTER TABLE "hex-api-go".users_devices DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE "hex-api-go".users_devices DROP COLUMN IF EXISTS last_ip;
+ALTER TABLE "hex-api-go".users_devices DROP COLUMN IF EXISTS user_agent;
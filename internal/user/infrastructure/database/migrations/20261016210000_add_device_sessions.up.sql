@@ -0,0 +1,8 @@
+-- devices are registered on login and touched on every refresh
+ALTER TABLE "hex-api-go".users_devices ADD COLUMN IF NOT EXISTS user_agent text NOT NULL DEFAULT '';
+ALTER TABLE "hex-api-go".users_devices ADD COLUMN IF NOT EXISTS last_ip text NOT NULL DEFAULT '';
+ALTER TABLE "hex-api-go".users_devices ADD COLUMN IF NOT EXISTS last_seen_at timestamptz;
+ALTER TABLE "hex-api-go".users_devices ADD COLUMN IF NOT EXISTS revoked_at timestamptz;
+
+UPDATE "hex-api-go".users_devices SET last_seen_at = COALESCE(updated_at, created_at, now()) WHERE last_seen_at IS NULL;
+ALTER TABLE "hex-api-go".users_devices ALTER COLUMN last_seen_at SET NOT NULL;
@@ -0,0 +1,5 @@
+DELETE FROM "hex-api-go".user_groups_permissions
+WHERE user_group_id IN (SELECT id FROM "hex-api-go".users_groups WHERE uuid = '00000000-0000-4000-8000-000000000001');
+DELETE FROM "hex-api-go".user_group_users
+WHERE user_group_id IN (SELECT id FROM "hex-api-go".users_groups WHERE uuid = '00000000-0000-4000-8000-000000000001');
+DELETE FROM "hex-api-go".users_groups WHERE uuid = '00000000-0000-4000-8000-000000000001';
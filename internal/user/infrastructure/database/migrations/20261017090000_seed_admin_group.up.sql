@@ -0,0 +1,26 @@
+INSERT INTO "hex-api-go".api_route_applications (created_at, updated_at, name)
+VALUES (now(), now(), 'users'), (now(), now(), 'groups')
+ON CONFLICT (name) DO NOTHING;
+
+-- the admins group holds every route permission; its members are granted at
+-- startup from USER_BOOTSTRAP_ADMINS
+INSERT INTO "hex-api-go".users_groups (created_at, updated_at, uuid, name)
+VALUES (now(), now(), '00000000-0000-4000-8000-000000000001', 'admins')
+ON CONFLICT (uuid) DO NOTHING;
+
+INSERT INTO "hex-api-go".user_groups_permissions (created_at, updated_at, api_route_application_id, action, user_group_id)
+SELECT now(), now(), application.id, permission.action, admins.id
+FROM (VALUES
+    ('users', 'read'), ('users', 'update'), ('users', 'delete'),
+    ('groups', 'create'), ('groups', 'update'), ('groups', 'delete'),
+    ('groups', 'manage-permissions'), ('groups', 'manage-users')
+) AS permission (resource, action)
+JOIN "hex-api-go".api_route_applications application ON application.name = permission.resource
+JOIN "hex-api-go".users_groups admins ON admins.uuid = '00000000-0000-4000-8000-000000000001'
+WHERE NOT EXISTS (
+    SELECT 1 FROM "hex-api-go".user_groups_permissions existing
+    WHERE existing.api_route_application_id = application.id
+      AND existing.action = permission.action
+      AND existing.user_group_id = admins.id
+      AND existing.deleted_at IS NULL
+);
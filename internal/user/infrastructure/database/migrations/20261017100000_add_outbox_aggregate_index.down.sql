@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "hex-api-go".idx_outbox_messages_pending_aggregate;
@@ -0,0 +1,2 @@
+-- the relay looks for older unsent messages of the same aggregate
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending_aggregate ON "hex-api-go".outbox_messages (aggregate_id, id) WHERE sent_at IS NULL;
@@ -0,0 +1,6 @@
+-- fails while a deleted row shares its username or document with a live one
+DROP INDEX IF EXISTS "hex-api-go".idx_users_username;
+CREATE UNIQUE INDEX idx_users_username ON "hex-api-go".users (username);
+
+DROP INDEX IF EXISTS "hex-api-go".idx_persons_document;
+CREATE UNIQUE INDEX idx_persons_document ON "hex-api-go".persons (document);
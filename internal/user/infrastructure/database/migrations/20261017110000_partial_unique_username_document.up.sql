@@ -0,0 +1,6 @@
+-- deleted users and persons free their username and document
+DROP INDEX IF EXISTS "hex-api-go".idx_users_username;
+CREATE UNIQUE INDEX idx_users_username ON "hex-api-go".users (username) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS "hex-api-go".idx_persons_document;
+CREATE UNIQUE INDEX idx_persons_document ON "hex-api-go".persons (document) WHERE deleted_at IS NULL;
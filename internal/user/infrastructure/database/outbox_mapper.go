@@ -0,0 +1,80 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/outbox"
+)
+
+type namedEvent interface {
+	ddgo.DomainEvent
+	Name() string
+}
+
+// versionedEvent is implemented by events whose payload had a breaking change;
+// every other event is published as version 1.
+type versionedEvent interface {
+	Version() int
+}
+
+// outboxMessagesToDatabase returns the messages in the order the events
+// occurred, the relay publishes the messages of an aggregate in id order.
+func outboxMessagesToDatabase(aggregateId string, events map[string]ddgo.DomainEvent) ([]OutboxMessages, error) {
+	ordered := slices.SortedFunc(maps.Values(events), func(a, b ddgo.DomainEvent) int {
+		if c := a.OcurredOn().Compare(b.OcurredOn()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Uuid(), b.Uuid())
+	})
+
+	messages := make([]OutboxMessages, 0, len(events))
+	for _, event := range ordered {
+		named, ok := event.(namedEvent)
+		if !ok {
+			return nil, ddgo.NewInternalError(fmt.Sprintf("event %s has no name to be routed by", event.Uuid()))
+		}
+
+		payload, err := json.Marshal(named)
+		if err != nil {
+			return nil, ddgo.NewInternalError(fmt.Sprintf("Error to serialize event %s: %s", named.Name(), err.Error()))
+		}
+
+		version := 1
+		if versioned, ok := event.(versionedEvent); ok {
+			version = versioned.Version()
+		}
+
+		messages = append(messages, OutboxMessages{
+			Uuid:          named.Uuid(),
+			EventName:     named.Name(),
+			AggregateId:   aggregateId,
+			Payload:       payload,
+			OccurredOn:    named.OcurredOn(),
+			Version:       version,
+			NextAttemptAt: named.OcurredOn(),
+		})
+	}
+
+	return messages, nil
+}
+
+func outboxMessageToRelay(message *OutboxMessages) *outbox.Message {
+	return &outbox.Message{
+		ID:            message.ID,
+		Uuid:          message.Uuid,
+		EventName:     message.EventName,
+		AggregateId:   message.AggregateId,
+		Payload:       message.Payload,
+		OccurredOn:    message.OccurredOn,
+		Version:       message.Version,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		SentAt:        message.SentAt,
+	}
+}
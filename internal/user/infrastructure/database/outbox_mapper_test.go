@@ -0,0 +1,47 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+type stubEvent struct {
+	uuid       string
+	name       string
+	occurredOn time.Time
+}
+
+func (e *stubEvent) Name() string         { return e.name }
+func (e *stubEvent) Payload() any         { return nil }
+func (e *stubEvent) OcurredOn() time.Time { return e.occurredOn }
+func (e *stubEvent) Uuid() string         { return e.uuid }
+
+func TestOutboxMessagesToDatabase(t *testing.T) {
+	t.Run("Should keep the order the events occurred when saved together", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		events := map[string]ddgo.DomainEvent{
+			"c": &stubEvent{uuid: "c", name: "usernameChanged", occurredOn: now.Add(time.Millisecond)},
+			"b": &stubEvent{uuid: "b", name: "userProfileUpdated", occurredOn: now},
+			"a": &stubEvent{uuid: "a", name: "userCreated", occurredOn: now},
+		}
+
+		// map iteration order is random, a single run could pass by chance
+		for range 20 {
+			messages, err := outboxMessagesToDatabase("aggregate", events)
+			if err != nil {
+				t.Fatalf("Should map the events, got: %v", err)
+			}
+
+			var uuids string
+			for _, message := range messages {
+				uuids += message.Uuid
+			}
+			if uuids != "abc" {
+				t.Fatalf("Should order the messages by occurrence then uuid, got: %s", uuids)
+			}
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package database
+
+import "github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+
+func refreshTokenToDomain(token *UserRefreshTokens) (*domain.RefreshToken, error) {
+	return domain.NewRefreshToken(&domain.RefreshTokenProps{
+		UuId:      token.Uuid,
+		UserId:    token.UserDevice.User.Uuid,
+		DeviceId:  token.UserDevice.DeviceId,
+		FamilyId:  token.FamilyId,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		RotatedAt: token.RotatedAt,
+		RevokedAt: token.RevokedAt,
+	})
+}
+
+func refreshTokenToDatabase(token *domain.RefreshToken, userDeviceId uint) *UserRefreshTokens {
+	return &UserRefreshTokens{
+		Uuid:         token.Uuid(),
+		UserDeviceId: userDeviceId,
+		FamilyId:     token.FamilyId(),
+		TokenHash:    token.TokenHash(),
+		ExpiresAt:    token.ExpiresAt(),
+		RotatedAt:    token.RotatedAt(),
+		RevokedAt:    token.RevokedAt(),
+	}
+}
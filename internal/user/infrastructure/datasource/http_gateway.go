@@ -0,0 +1,125 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+)
+
+const HTTPGatewayName = "http"
+
+// personPayload is the body of GET {baseURL}/users/{id}/person.
+type personPayload struct {
+	Id        string           `json:"id"`
+	Name      string           `json:"name"`
+	BirthDate string           `json:"birthDate"`
+	Document  string           `json:"document"`
+	Contacts  []contactPayload `json:"contacts"`
+}
+
+type contactPayload struct {
+	Id    string `json:"id"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Main  bool   `json:"main"`
+}
+
+// HTTPGateway reads the person from an external people API.
+type HTTPGateway struct {
+	name    string
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPGateway uses http.DefaultClient when client is nil, the registry
+// bounds every call with the gateway timeout.
+func NewHTTPGateway(name string, baseURL string, client *http.Client) *HTTPGateway {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &HTTPGateway{
+		name:    name,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+}
+
+func (g *HTTPGateway) Name() string {
+	return g.name
+}
+
+func (g *HTTPGateway) GetPerson(ctx context.Context, userId string) (*domain.Person, error) {
+	endpoint := fmt.Sprintf("%s/users/%s/person", g.baseURL, url.PathEscape(userId))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, ddgo.NewInternalError(fmt.Sprintf("person gateway %s: %s", g.name, err.Error()))
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := g.client.Do(request)
+	if err != nil {
+		return nil, ddgo.NewDependencyError(fmt.Sprintf("person gateway %s: %s", g.name, err.Error()))
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ddgo.NewNotFoundError(fmt.Sprintf("person of user %s not found in %s", userId, g.name))
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, ddgo.NewDependencyError(fmt.Sprintf("person gateway %s answered %d", g.name, response.StatusCode))
+	}
+
+	var payload personPayload
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		return nil, ddgo.NewDependencyError(fmt.Sprintf("person gateway %s sent an invalid body: %s", g.name, err.Error()))
+	}
+
+	person, err := payload.toDomain()
+	if err != nil {
+		return nil, ddgo.NewDependencyError(fmt.Sprintf("person gateway %s sent an invalid person: %s", g.name, err.Error()))
+	}
+
+	return person, nil
+}
+
+func (p *personPayload) toDomain() (*domain.Person, error) {
+	document, err := domain.NewDocument(&domain.DocumentProps{Value: p.Document})
+	if err != nil {
+		return nil, err
+	}
+
+	birthDate, err := domain.NewBirthDate(&domain.BirthDateProps{Value: p.BirthDate})
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*domain.Contact, 0, len(p.Contacts))
+	for _, payload := range p.Contacts {
+		contact, err := domain.NewContact(&domain.ContactProps{
+			UuId:        payload.Id,
+			Description: payload.Value,
+			ContactType: payload.Type,
+			Main:        payload.Main,
+		})
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return domain.NewPerson(&domain.PersonProps{
+		UuId:      p.Id,
+		Name:      p.Name,
+		BirthDate: birthDate,
+		Document:  document,
+		Contacts:  contacts,
+	})
+}
@@ -0,0 +1,101 @@
+package datasource_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/datasource"
+)
+
+const validPersonBody = `{
+	"id": "person-uuid-1",
+	"name": "John Doe",
+	"birthDate": "1990-01-01",
+	"document": "529.982.247-25",
+	"contacts": [{"id": "contact-uuid-1", "type": "email", "value": "john@example.com", "main": true}]
+}`
+
+func newPeopleAPI(t *testing.T, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/user-uuid-1/person" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPGatewayGetPerson(t *testing.T) {
+	t.Run("Should build the person from the external api", func(t *testing.T) {
+		t.Parallel()
+		server := newPeopleAPI(t, http.StatusOK, validPersonBody)
+		gateway := datasource.NewHTTPGateway("people", server.URL+"/", server.Client())
+
+		person, err := gateway.GetPerson(context.Background(), "user-uuid-1")
+		if err != nil {
+			t.Fatalf("Should get person, got: %v", err)
+		}
+
+		if person.Uuid() != "person-uuid-1" || person.Document().Value() != "52998224725" {
+			t.Errorf("Should return person-uuid-1 with document 52998224725, got: %s %s", person.Uuid(), person.Document().Value())
+		}
+
+		if person.MainContact() == nil || person.MainContact().Description() != "john@example.com" {
+			t.Errorf("Should return main contact john@example.com, got: %v", person.MainContact())
+		}
+	})
+
+	tests := []struct {
+		description string
+		userId      string
+		status      int
+		body        string
+		check       func(err error) bool
+	}{
+		{
+			description: "Should return NotFoundError when the api does not know the user",
+			userId:      "user-uuid-2",
+			status:      http.StatusOK,
+			check:       func(err error) bool { _, ok := err.(*ddgo.NotFoundError); return ok },
+		},
+		{
+			description: "Should return DependencyError when the api fails",
+			userId:      "user-uuid-1",
+			status:      http.StatusServiceUnavailable,
+			check:       func(err error) bool { _, ok := err.(*ddgo.DependencyError); return ok },
+		},
+		{
+			description: "Should return DependencyError for an invalid body",
+			userId:      "user-uuid-1",
+			status:      http.StatusOK,
+			body:        `{"id":`,
+			check:       func(err error) bool { _, ok := err.(*ddgo.DependencyError); return ok },
+		},
+		{
+			description: "Should return DependencyError for an invalid person",
+			userId:      "user-uuid-1",
+			status:      http.StatusOK,
+			body:        `{"id":"person-uuid-1","name":"John Doe","birthDate":"1990-01-01","document":"123"}`,
+			check:       func(err error) bool { _, ok := err.(*ddgo.DependencyError); return ok },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			server := newPeopleAPI(t, tt.status, tt.body)
+			gateway := datasource.NewHTTPGateway("people", server.URL, server.Client())
+
+			_, err := gateway.GetPerson(context.Background(), tt.userId)
+			if !tt.check(err) {
+				t.Errorf("Should return the expected error type, got: %T %v", err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+const LocalGatewayName = "local"
+
+// LocalGateway reads the person from the module database.
+type LocalGateway struct {
+	repository contract.UserRepository
+}
+
+func NewLocalGateway(repository contract.UserRepository) *LocalGateway {
+	return &LocalGateway{repository: repository}
+}
+
+func (g *LocalGateway) Name() string {
+	return LocalGatewayName
+}
+
+func (g *LocalGateway) GetPerson(ctx context.Context, userId string) (*domain.Person, error) {
+	user, err := g.repository.FindByUuid(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Person(), nil
+}
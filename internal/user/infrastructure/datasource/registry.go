@@ -0,0 +1,164 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+const (
+	defaultGatewayTimeout   = 2 * time.Second
+	defaultBreakerFailures  = 5
+	defaultBreakerOpenAfter = 30 * time.Second
+)
+
+type GatewayConfig struct {
+	Timeout          time.Duration
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+func DefaultGatewayConfig() GatewayConfig {
+	return GatewayConfig{
+		Timeout:          defaultGatewayTimeout,
+		FailureThreshold: defaultBreakerFailures,
+		OpenTimeout:      defaultBreakerOpenAfter,
+	}
+}
+
+type guardedGateway struct {
+	gateway contract.PersonGateway
+	timeout time.Duration
+	breaker *circuitBreaker
+}
+
+// Registry is the contract.UserDataSource of the module: every registered
+// gateway runs with its own timeout and circuit breaker.
+type Registry struct {
+	gateways       map[string]*guardedGateway
+	defaultGateway string
+	selected       string
+	now            func() time.Time
+}
+
+func NewRegistry(defaultGateway string) *Registry {
+	return &Registry{
+		gateways:       map[string]*guardedGateway{},
+		defaultGateway: defaultGateway,
+		now:            time.Now,
+	}
+}
+
+// NewRegistryFromEnv registers the local gateway and, when
+// USER_DATASOURCE_HTTP_URL is set, the HTTP one.
+func NewRegistryFromEnv(local contract.PersonGateway) (*Registry, error) {
+	config := DefaultGatewayConfig()
+	if value := os.Getenv("USER_DATASOURCE_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("USER_DATASOURCE_TIMEOUT must be a positive duration, got %q", value)
+		}
+		config.Timeout = timeout
+	}
+
+	if value := os.Getenv("USER_DATASOURCE_BREAKER_FAILURES"); value != "" {
+		failures, err := strconv.Atoi(value)
+		if err != nil || failures < 1 {
+			return nil, fmt.Errorf("USER_DATASOURCE_BREAKER_FAILURES must be a positive integer, got %q", value)
+		}
+		config.FailureThreshold = failures
+	}
+
+	if value := os.Getenv("USER_DATASOURCE_BREAKER_OPEN"); value != "" {
+		openTimeout, err := time.ParseDuration(value)
+		if err != nil || openTimeout <= 0 {
+			return nil, fmt.Errorf("USER_DATASOURCE_BREAKER_OPEN must be a positive duration, got %q", value)
+		}
+		config.OpenTimeout = openTimeout
+	}
+
+	registry := NewRegistry(local.Name()).Register(local, config)
+	if value := os.Getenv("USER_DATASOURCE_HTTP_URL"); value != "" {
+		registry.Register(NewHTTPGateway(HTTPGatewayName, value, nil), config)
+	}
+
+	return registry, nil
+}
+
+func (r *Registry) Register(gateway contract.PersonGateway, config GatewayConfig) *Registry {
+	r.gateways[gateway.Name()] = &guardedGateway{
+		gateway: gateway,
+		timeout: config.Timeout,
+		breaker: newCircuitBreaker(config.FailureThreshold, config.OpenTimeout, r.now),
+	}
+	return r
+}
+
+func (r *Registry) WithGateway(gateway string) contract.UserDataSource {
+	selected := *r
+	selected.selected = gateway
+	return &selected
+}
+
+func (r *Registry) GetPerson(ctx context.Context, userId string) (*contract.SourcedPerson, error) {
+	name := r.selected
+	if name == "" {
+		name = r.defaultGateway
+	}
+
+	guarded, ok := r.gateways[name]
+	if !ok {
+		return nil, unknownGateway()
+	}
+
+	if !guarded.breaker.allow() {
+		return nil, ddgo.NewDependencyError(fmt.Sprintf("person gateway %s is unavailable", name))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, guarded.timeout)
+	defer cancel()
+
+	person, err := guarded.gateway.GetPerson(ctx, userId)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		guarded.breaker.failure()
+		return nil, ddgo.NewDependencyError(fmt.Sprintf("person gateway %s timed out", name))
+	}
+
+	if err != nil {
+		if countsAsFailure(err) {
+			guarded.breaker.failure()
+		} else {
+			guarded.breaker.success()
+		}
+		return nil, err
+	}
+
+	guarded.breaker.success()
+	return &contract.SourcedPerson{
+		Person:    person,
+		Origin:    name,
+		FetchedAt: r.now().UTC(),
+	}, nil
+}
+
+// countsAsFailure leaves out answers about the data itself, a gateway saying
+// a person does not exist is working fine.
+func countsAsFailure(err error) bool {
+	var notFound *ddgo.NotFoundError
+	var invalidData *ddgo.InvalidDataError
+	return !errors.As(err, &notFound) && !errors.As(err, &invalidData)
+}
+
+func unknownGateway() error {
+	result, _ := json.Marshal(map[string]any{
+		"DataSource": map[string]any{"IsValid": false, "FailedValidators": []string{"oneof"}},
+	})
+	return ddgo.NewInvalidDataError(string(result))
+}
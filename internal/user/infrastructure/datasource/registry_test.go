@@ -0,0 +1,140 @@
+package datasource_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/datasource"
+)
+
+type fakeGateway struct {
+	name  string
+	calls atomic.Int32
+	err   error
+	delay time.Duration
+}
+
+func (g *fakeGateway) Name() string {
+	return g.name
+}
+
+func (g *fakeGateway) GetPerson(ctx context.Context, userId string) (*domain.Person, error) {
+	g.calls.Add(1)
+	select {
+	case <-time.After(g.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if g.err != nil {
+		return nil, g.err
+	}
+	return &domain.Person{}, nil
+}
+
+func TestRegistryGetPerson(t *testing.T) {
+	config := datasource.GatewayConfig{Timeout: time.Second, FailureThreshold: 2, OpenTimeout: time.Hour}
+
+	t.Run("Should tag the person with the selected gateway", func(t *testing.T) {
+		t.Parallel()
+		server := newPeopleAPI(t, http.StatusOK, validPersonBody)
+		registry := datasource.NewRegistry("local").
+			Register(&fakeGateway{name: "local"}, config).
+			Register(datasource.NewHTTPGateway("people", server.URL, server.Client()), config)
+
+		sourced, err := registry.WithGateway("people").GetPerson(context.Background(), "user-uuid-1")
+		if err != nil {
+			t.Fatalf("Should get person, got: %v", err)
+		}
+
+		if sourced.Origin != "people" || sourced.FetchedAt.IsZero() {
+			t.Errorf("Should tag origin people with fetch time, got: %s %v", sourced.Origin, sourced.FetchedAt)
+		}
+
+		sourced, _ = registry.GetPerson(context.Background(), "user-uuid-1")
+		if sourced.Origin != "local" {
+			t.Errorf("Should use the default gateway, got: %s", sourced.Origin)
+		}
+	})
+
+	t.Run("Should return InvalidDataError for unknown gateway", func(t *testing.T) {
+		t.Parallel()
+		registry := datasource.NewRegistry("local").Register(&fakeGateway{name: "local"}, config)
+
+		_, err := registry.WithGateway("crm").GetPerson(context.Background(), "user-uuid-1")
+		if _, ok := err.(*ddgo.InvalidDataError); !ok {
+			t.Errorf("Should return InvalidDataError, got: %v", err)
+		}
+	})
+
+	t.Run("Should return DependencyError when the gateway times out", func(t *testing.T) {
+		t.Parallel()
+		slow := &fakeGateway{name: "slow", delay: time.Second}
+		registry := datasource.NewRegistry("slow").
+			Register(slow, datasource.GatewayConfig{Timeout: 10 * time.Millisecond, FailureThreshold: 5, OpenTimeout: time.Hour})
+
+		_, err := registry.GetPerson(context.Background(), "user-uuid-1")
+		if _, ok := err.(*ddgo.DependencyError); !ok {
+			t.Errorf("Should return DependencyError, got: %v", err)
+		}
+	})
+
+	t.Run("Should stop calling the gateway once the circuit opens", func(t *testing.T) {
+		t.Parallel()
+		failing := &fakeGateway{name: "failing", err: ddgo.NewDependencyError("down")}
+		registry := datasource.NewRegistry("failing").Register(failing, config)
+
+		for range 4 {
+			registry.GetPerson(context.Background(), "user-uuid-1")
+		}
+
+		if failing.calls.Load() != 2 {
+			t.Errorf("Should call the gateway 2 times, got: %d", failing.calls.Load())
+		}
+	})
+
+	t.Run("Should not open the circuit for unknown people", func(t *testing.T) {
+		t.Parallel()
+		missing := &fakeGateway{name: "missing", err: ddgo.NewNotFoundError("person not found")}
+		registry := datasource.NewRegistry("missing").Register(missing, config)
+
+		for range 4 {
+			_, err := registry.GetPerson(context.Background(), "user-uuid-1")
+			var notFound *ddgo.NotFoundError
+			if !errors.As(err, &notFound) {
+				t.Fatalf("Should return NotFoundError, got: %v", err)
+			}
+		}
+
+		if missing.calls.Load() != 4 {
+			t.Errorf("Should call the gateway 4 times, got: %d", missing.calls.Load())
+		}
+	})
+
+	t.Run("Should probe the gateway again after the open timeout", func(t *testing.T) {
+		t.Parallel()
+		failing := &fakeGateway{name: "failing", err: ddgo.NewDependencyError("down")}
+		registry := datasource.NewRegistry("failing").
+			Register(failing, datasource.GatewayConfig{Timeout: time.Second, FailureThreshold: 1, OpenTimeout: 20 * time.Millisecond})
+
+		registry.GetPerson(context.Background(), "user-uuid-1")
+		registry.GetPerson(context.Background(), "user-uuid-1")
+		time.Sleep(30 * time.Millisecond)
+		failing.err = nil
+
+		_, err := registry.GetPerson(context.Background(), "user-uuid-1")
+		if err != nil {
+			t.Fatalf("Should close the circuit after a good probe, got: %v", err)
+		}
+
+		if failing.calls.Load() != 2 {
+			t.Errorf("Should call the gateway 2 times, got: %d", failing.calls.Load())
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/addgroupuser"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type AddGroupUserRequest struct {
+	UserId string `json:"userId" binding:"required,uuid"`
+}
+
+func AddGroupUserHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id/users"
+	router.POST(uri, guard.Require(manageGroupUsersPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var params GroupUri
+		if err := c.ShouldBindUri(&params); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		var request AddGroupUserRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &addgroupuser.Command{
+			GroupId: params.Id,
+			UserId:  request.UserId,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
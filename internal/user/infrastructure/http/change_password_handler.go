@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/changepassword"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+)
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required"`
+}
+
+// ChangePasswordHandler changes the password of the authenticated user, no
+// permission is needed for one's own password.
+func ChangePasswordHandler(router *gin.RouterGroup, db *gorm.DB, guard *http.AuthGuard) {
+	uri := "/password"
+	router.PUT(uri, guard.Authenticate(), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request ChangePasswordRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		principal, _ := http.PrincipalFromContext(c)
+		// the new password and the signed out sessions commit together.
+		err := postgres.Transaction(ctx, db, func(ctx context.Context, tx *gorm.DB) error {
+			bus, _ := gomes.CommandBus()
+			_, err := bus.Send(ctx, &changepassword.Command{
+				UserId:          principal.UserId,
+				CurrentPassword: request.CurrentPassword,
+				NewPassword:     request.NewPassword,
+			})
+			return err
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
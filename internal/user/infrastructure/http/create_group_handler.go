@@ -0,0 +1,39 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/creategroup"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type CreateGroupRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func CreateGroupHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/"
+	router.POST(uri, guard.Require(createGroupPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request CreateGroupRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		res, err := bus.Send(ctx, &creategroup.Command{
+			GroupName: request.Name,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		http.Success(c, httpLib.StatusCreated, res)
+	})
+}
@@ -1,37 +1,27 @@
 package http
 
 import (
-	"fmt"
-
 	httpLib "net/http"
 
 	"github.com/gin-gonic/gin"
 	gomes "github.com/jeffersonbrasilino/gomes"
-	"github.com/jeffersonbrasilino/gomes/otel"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/createuser"
 	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
 )
 
-var createUserTrace = otel.InitTrace("create-user-handler")
-
 type CreateUserRequest struct {
 	Username   string `json:"username" binding:"required"`
 	Password   string `json:"password" binding:"required"`
 	PersonName string `json:"name" binding:"required"`
 	Document   string `json:"document" binding:"required"`
-	BirthDate  string `json:"birthDate" binding:"required"`
+	BirthDate  string `json:"birthDate" binding:"required,len=10"`
 	Email      string `json:"email" binding:"required"`
 }
 
 func CreateUserHandler(router *gin.RouterGroup) {
 	uri := "/create"
 	router.POST(uri, func(c *gin.Context) {
-		ctx, span := createUserTrace.Start(
-			c,
-			fmt.Sprintf("post %s", uri),
-			otel.WithSpanKind(otel.SpanKindServer),
-		)
-		defer span.End()
+		ctx := c.Request.Context()
 
 		var request CreateUserRequest
 		if err := c.ShouldBindJSON(&request); err != nil {
@@ -0,0 +1,35 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/deletegroup"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+func DeleteGroupHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id"
+	router.DELETE(uri, guard.Require(deleteGroupPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request GroupUri
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &deletegroup.Command{
+			GroupId: request.Id,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/deleteuser"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+func DeleteUserHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id"
+	router.DELETE(uri, guard.Require(deleteUserPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request GetUserRequest
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &deleteuser.Command{
+			UserId: request.Id,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
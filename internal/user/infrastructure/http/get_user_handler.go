@@ -0,0 +1,50 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/getuser"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type GetUserRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
+
+type GetUserQuery struct {
+	DataSource string `form:"dataSource"`
+}
+
+func GetUserHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id"
+	router.GET(uri, guard.Require(readUserPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request GetUserRequest
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		var query GetUserQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		getUser := getuser.NewQuery(request.Id)
+		getUser.DataSource = query.DataSource
+
+		bus, _ := gomes.QueryBus()
+		res, err := bus.Send(ctx, getUser)
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Header("ETag", http.ETag(res.(*getuser.Response).Version))
+		http.Success(c, httpLib.StatusOK, res)
+	})
+}
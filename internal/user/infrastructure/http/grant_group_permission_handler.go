@@ -0,0 +1,48 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/grantgrouppermission"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type GrantGroupPermissionRequest struct {
+	Resource string `json:"resource" binding:"required"`
+	Action   string `json:"action" binding:"required"`
+}
+
+func GrantGroupPermissionHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id/permissions"
+	router.POST(uri, guard.Require(manageGroupPermissionsPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var params GroupUri
+		if err := c.ShouldBindUri(&params); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		var request GrantGroupPermissionRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &grantgrouppermission.Command{
+			GroupId:  params.Id,
+			Resource: request.Resource,
+			Action:   request.Action,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,8 @@
+package http
+
+// GroupUri binds the group id shared by every /groups/:id route. It is kept
+// apart from the JSON request structs because gin validates the whole struct
+// on each bind call.
+type GroupUri struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listdevices"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+// ListDevicesHandler lists the sessions of the authenticated user, marking
+// the device of the access token as current.
+func ListDevicesHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/devices"
+	router.GET(uri, guard.Authenticate(), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		principal, _ := http.PrincipalFromContext(c)
+		bus, _ := gomes.QueryBus()
+		res, err := bus.Send(ctx, &listdevices.Query{
+			UserId:          principal.UserId,
+			CurrentDeviceId: principal.DeviceId,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		http.Success(c, httpLib.StatusOK, res)
+	})
+}
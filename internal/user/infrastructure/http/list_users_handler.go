@@ -0,0 +1,59 @@
+package http
+
+import (
+	"time"
+
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listusers"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type ListUsersRequest struct {
+	Username    string     `form:"username"`
+	Document    string     `form:"document"`
+	Group       string     `form:"group" binding:"omitempty,uuid"`
+	Status      string     `form:"status" binding:"omitempty,oneof=active inactive"`
+	CreatedFrom *time.Time `form:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"createdTo" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort        string     `form:"sort" binding:"omitempty,oneof=createdAt -createdAt username -username"`
+	Cursor      string     `form:"cursor"`
+	Offset      int        `form:"offset" binding:"gte=0"`
+	Limit       int        `form:"limit" binding:"gte=0,lte=100"`
+}
+
+func ListUsersHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := ""
+	router.GET(uri, guard.Require(readUserPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request ListUsersRequest
+		if err := c.ShouldBindQuery(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.QueryBus()
+		res, err := bus.Send(ctx, &listusers.Query{
+			UsernamePrefix: request.Username,
+			Document:       request.Document,
+			GroupId:        request.Group,
+			Status:         request.Status,
+			CreatedFrom:    request.CreatedFrom,
+			CreatedTo:      request.CreatedTo,
+			Sort:           request.Sort,
+			Cursor:         request.Cursor,
+			Offset:         request.Offset,
+			Limit:          request.Limit,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		http.Success(c, httpLib.StatusOK, res)
+	})
+}
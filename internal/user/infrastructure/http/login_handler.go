@@ -0,0 +1,45 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/auth"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	DeviceId string `json:"deviceId" binding:"omitempty,max=255"`
+}
+
+func LoginHandler(router *gin.RouterGroup) {
+	uri := "/login"
+	router.POST(uri, func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request LoginRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		res, err := bus.Send(ctx, &auth.LoginCommand{
+			Username:  request.Username,
+			Password:  request.Password,
+			DeviceId:  request.DeviceId,
+			Ip:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		http.Success(c, httpLib.StatusOK, res)
+	})
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/auth"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+func LogoutHandler(router *gin.RouterGroup) {
+	uri := "/logout"
+	router.POST(uri, func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request LogoutRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &auth.LogoutCommand{
+			RefreshToken: request.RefreshToken,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
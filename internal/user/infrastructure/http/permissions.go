@@ -0,0 +1,20 @@
+package http
+
+import "github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+
+const (
+	usersResource  = "users"
+	groupsResource = "groups"
+)
+
+var (
+	readUserPermission   = http.Permission{Resource: usersResource, Action: "read"}
+	updateUserPermission = http.Permission{Resource: usersResource, Action: "update"}
+	deleteUserPermission = http.Permission{Resource: usersResource, Action: "delete"}
+
+	createGroupPermission            = http.Permission{Resource: groupsResource, Action: "create"}
+	updateGroupPermission            = http.Permission{Resource: groupsResource, Action: "update"}
+	deleteGroupPermission            = http.Permission{Resource: groupsResource, Action: "delete"}
+	manageGroupPermissionsPermission = http.Permission{Resource: groupsResource, Action: "manage-permissions"}
+	manageGroupUsersPermission       = http.Permission{Resource: groupsResource, Action: "manage-users"}
+)
@@ -0,0 +1,35 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/reactivateuser"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+func ReactivateUserHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id/reactivate"
+	router.POST(uri, guard.Require(updateUserPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request GetUserRequest
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &reactivateuser.Command{
+			UserId: request.Id,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/auth"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+func RefreshTokenHandler(router *gin.RouterGroup) {
+	uri := "/refresh"
+	router.POST(uri, func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request RefreshTokenRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		res, err := bus.Send(ctx, &auth.RefreshCommand{
+			RefreshToken: request.RefreshToken,
+			Ip:           c.ClientIP(),
+			UserAgent:    c.Request.UserAgent(),
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		http.Success(c, httpLib.StatusOK, res)
+	})
+}
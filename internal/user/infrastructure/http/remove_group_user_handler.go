@@ -0,0 +1,41 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/removegroupuser"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type RemoveGroupUserRequest struct {
+	Id     string `uri:"id" binding:"required,uuid"`
+	UserId string `uri:"userId" binding:"required,uuid"`
+}
+
+func RemoveGroupUserHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id/users/:userId"
+	router.DELETE(uri, guard.Require(manageGroupUsersPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request RemoveGroupUserRequest
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &removegroupuser.Command{
+			GroupId: request.Id,
+			UserId:  request.UserId,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/renamegroup"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type RenameGroupRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func RenameGroupHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id"
+	router.PATCH(uri, guard.Require(updateGroupPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var params GroupUri
+		if err := c.ShouldBindUri(&params); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		var request RenameGroupRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &renamegroup.Command{
+			GroupId:   params.Id,
+			GroupName: request.Name,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/requestpasswordreset"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type RequestPasswordResetRequest struct {
+	Login string `json:"login" binding:"required,max=255"`
+}
+
+// RequestPasswordResetHandler is public and answers 202 whether or not the
+// login exists, so it cannot be used to find accounts.
+func RequestPasswordResetHandler(router *gin.RouterGroup) {
+	uri := "/password/forgot"
+	router.POST(uri, func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request RequestPasswordResetRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &requestpasswordreset.Command{
+			Login: request.Login,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusAccepted)
+	})
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/resendverification"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+// ResendVerificationHandler is public, the resend cooldown keeps it from
+// flooding the inbox.
+func ResendVerificationHandler(router *gin.RouterGroup) {
+	uri := "/:id/verify/resend"
+	router.POST(uri, func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var params GetUserRequest
+		if err := c.ShouldBindUri(&params); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &resendverification.Command{
+			UserId: params.Id,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusAccepted)
+	})
+}
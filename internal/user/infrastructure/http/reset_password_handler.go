@@ -0,0 +1,49 @@
+package http
+
+import (
+	"context"
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/resetpassword"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+)
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required,max=255"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ResetPasswordHandler is public: the token sent to the email is the proof.
+func ResetPasswordHandler(router *gin.RouterGroup, db *gorm.DB) {
+	uri := "/password/reset"
+	router.POST(uri, func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request ResetPasswordRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		// the token is spent only if every session is signed out as well.
+		err := postgres.Transaction(ctx, db, func(ctx context.Context, tx *gorm.DB) error {
+			bus, _ := gomes.CommandBus()
+			_, err := bus.Send(ctx, &resetpassword.Command{
+				Token:    request.Token,
+				Password: request.Password,
+			})
+			return err
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/revokedevice"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type RevokeDeviceRequest struct {
+	DeviceId string `uri:"deviceId" binding:"required,max=255"`
+}
+
+func RevokeDeviceHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/devices/:deviceId"
+	router.DELETE(uri, guard.Authenticate(), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request RevokeDeviceRequest
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		principal, _ := http.PrincipalFromContext(c)
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &revokedevice.Command{
+			UserId:   principal.UserId,
+			DeviceId: request.DeviceId,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/revokegrouppermission"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type RevokeGroupPermissionRequest struct {
+	Id       string `uri:"id" binding:"required,uuid"`
+	Resource string `uri:"resource" binding:"required"`
+	Action   string `uri:"action" binding:"required"`
+}
+
+func RevokeGroupPermissionHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id/permissions/:resource/:action"
+	router.DELETE(uri, guard.Require(manageGroupPermissionsPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request RevokeGroupPermissionRequest
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &revokegrouppermission.Command{
+			GroupId:  request.Id,
+			Resource: request.Resource,
+			Action:   request.Action,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/revokeotherdevices"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+// RevokeOtherDevicesHandler keeps only the device of the access token signed
+// in.
+func RevokeOtherDevicesHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/devices/revoke-others"
+	router.POST(uri, guard.Authenticate(), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		principal, _ := http.PrincipalFromContext(c)
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &revokeotherdevices.Command{
+			UserId:          principal.UserId,
+			CurrentDeviceId: principal.DeviceId,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
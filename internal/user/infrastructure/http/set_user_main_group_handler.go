@@ -0,0 +1,41 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/setusermaingroup"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type SetUserMainGroupRequest struct {
+	Id     string `uri:"id" binding:"required,uuid"`
+	UserId string `uri:"userId" binding:"required,uuid"`
+}
+
+func SetUserMainGroupHandler(router *gin.RouterGroup, guard *http.AuthGuard) {
+	uri := "/:id/users/:userId/main"
+	router.PUT(uri, guard.Require(manageGroupUsersPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var request SetUserMainGroupRequest
+		if err := c.ShouldBindUri(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &setusermaingroup.Command{
+			GroupId: request.Id,
+			UserId:  request.UserId,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"errors"
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/changeusername"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/updateuserprofile"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/getuser"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"gorm.io/gorm"
+)
+
+// UpdateUserRequest is a JSON Merge Patch document: absent members are kept,
+// a null contacts list removes every contact.
+type UpdateUserRequest struct {
+	Username http.Optional[string]              `json:"username"`
+	Person   http.Optional[UpdatePersonRequest] `json:"person"`
+}
+
+type UpdatePersonRequest struct {
+	Name      http.Optional[string]                       `json:"name"`
+	BirthDate http.Optional[string]                       `json:"birthDate"`
+	Contacts  http.Optional[[]*updateuserprofile.Contact] `json:"contacts"`
+}
+
+func UpdateUserHandler(router *gin.RouterGroup, db *gorm.DB, guard *http.AuthGuard) {
+	uri := "/:id"
+	router.PATCH(uri, guard.Require(updateUserPermission), func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var params GetUserRequest
+		if err := c.ShouldBindUri(&params); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		if c.GetHeader("If-Match") == "" {
+			http.ErrorWithCode(c, httpLib.StatusPreconditionRequired, errors.New("If-Match header with the user ETag is required"))
+			return
+		}
+
+		version, err := http.IfMatchVersion(c.GetHeader("If-Match"))
+		if err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		var request UpdateUserRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		if request.Person.Null {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, errors.New("person can not be null, leave it out to keep it"))
+			return
+		}
+
+		// both changes commit together, a rejected username must not leave the
+		// profile updated behind the ETag the client still holds.
+		err = postgres.Transaction(ctx, db, func(ctx context.Context, tx *gorm.DB) error {
+			bus, _ := gomes.CommandBus()
+			if request.Person.Present {
+				person := request.Person.Value
+				command := &updateuserprofile.Command{
+					UserId:     params.Id,
+					Version:    version,
+					PersonName: person.Name.Pointer(),
+					BirthDate:  person.BirthDate.Pointer(),
+				}
+
+				if person.Contacts.Present {
+					command.Contacts = append([]*updateuserprofile.Contact{}, person.Contacts.Value...)
+				}
+
+				res, err := bus.Send(ctx, command)
+				if err != nil {
+					return err
+				}
+				version = res.(int)
+			}
+
+			if request.Username.Present {
+				_, err := bus.Send(ctx, &changeusername.Command{
+					UserId:   params.Id,
+					Version:  version,
+					Username: request.Username.Value,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		queryBus, _ := gomes.QueryBus()
+		res, err := queryBus.Send(ctx, getuser.NewQuery(params.Id))
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Header("ETag", http.ETag(res.(*getuser.Response).Version))
+		http.Success(c, httpLib.StatusOK, res)
+	})
+}
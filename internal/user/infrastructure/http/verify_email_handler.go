@@ -0,0 +1,47 @@
+package http
+
+import (
+	httpLib "net/http"
+
+	"github.com/gin-gonic/gin"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/verifyemail"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type VerifyEmailRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// VerifyEmailHandler is public: the code sent to the email is the proof.
+func VerifyEmailHandler(router *gin.RouterGroup) {
+	uri := "/:id/verify"
+	router.POST(uri, func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var params GetUserRequest
+		if err := c.ShouldBindUri(&params); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		var request VerifyEmailRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			http.ErrorWithCode(c, httpLib.StatusBadRequest, err)
+			return
+		}
+
+		bus, _ := gomes.CommandBus()
+		_, err := bus.Send(ctx, &verifyemail.Command{
+			UserId: params.Id,
+			Code:   request.Code,
+		})
+
+		if err != nil {
+			http.Error(c, err)
+			return
+		}
+
+		c.Status(httpLib.StatusNoContent)
+	})
+}
@@ -0,0 +1,40 @@
+package messaging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jeffersonbrasilino/gomes/message/handler"
+)
+
+type appliedHandler[T handler.Action, U any] struct {
+	handler handler.ActionHandler[T, U]
+	applied func(err error) bool
+}
+
+// AcknowledgeApplied wraps an action handler so an event whose change is
+// already in place, ex: deactivating a user that is inactive, is acknowledged
+// instead of retried until it is dead lettered. applied tells those errors
+// apart. Calls that did not come through the InboundEventTranslator get every
+// error back.
+func AcknowledgeApplied[T handler.Action, U any](
+	next handler.ActionHandler[T, U],
+	applied func(err error) bool,
+) handler.ActionHandler[T, U] {
+	return &appliedHandler[T, U]{
+		handler: next,
+		applied: applied,
+	}
+}
+
+func (h *appliedHandler[T, U]) Handle(ctx context.Context, action T) (U, error) {
+	output, err := h.handler.Handle(ctx, action)
+	eventId := eventIdFromContext(ctx)
+	if err == nil || eventId == "" || !h.applied(err) {
+		return output, err
+	}
+
+	slog.InfoContext(ctx, "event already applied, acknowledging it", "action", action.Name(), "eventId", eventId)
+	var none U
+	return none, nil
+}
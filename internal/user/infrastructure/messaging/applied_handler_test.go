@@ -0,0 +1,50 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/messaging"
+)
+
+var errAlreadyApplied = errors.New("user is already inactive")
+
+func isAlreadyApplied(err error) bool {
+	return errors.Is(err, errAlreadyApplied)
+}
+
+func TestAcknowledgeAppliedHandle(t *testing.T) {
+	t.Run("Should acknowledge a redelivered event whose change is applied", func(t *testing.T) {
+		t.Parallel()
+		next := &countingHandler{err: errAlreadyApplied}
+		applied := messaging.AcknowledgeApplied[*syncCommand, any](next, isAlreadyApplied)
+		idempotent := messaging.Idempotent[*syncCommand, any]("users", &memoryProcessedStore{processed: map[string]bool{}}, applied)
+
+		for _, eventId := range []string{"event-1", "event-2"} {
+			if _, err := idempotent.Handle(translatedContext(t, eventId), &syncCommand{}); err != nil {
+				t.Errorf("Should acknowledge event %s, got: %v", eventId, err)
+			}
+		}
+	})
+
+	t.Run("Should return the other errors so the event is retried", func(t *testing.T) {
+		t.Parallel()
+		next := &countingHandler{err: errors.New("database unavailable")}
+		applied := messaging.AcknowledgeApplied[*syncCommand, any](next, isAlreadyApplied)
+
+		if _, err := applied.Handle(translatedContext(t, "event-1"), &syncCommand{}); err == nil {
+			t.Error("Should return the action error")
+		}
+	})
+
+	t.Run("Should return the error to calls that did not come from a consumer", func(t *testing.T) {
+		t.Parallel()
+		next := &countingHandler{err: errAlreadyApplied}
+		applied := messaging.AcknowledgeApplied[*syncCommand, any](next, isAlreadyApplied)
+
+		if _, err := applied.Handle(context.Background(), &syncCommand{}); !errors.Is(err, errAlreadyApplied) {
+			t.Errorf("Should return the action error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,205 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/gomes/channel/kafka"
+	"github.com/jeffersonbrasilino/gomes/message/endpoint"
+	"github.com/jeffersonbrasilino/gomes/message/handler"
+)
+
+const (
+	consumersConnectionName   = "user-consumers-kafka"
+	defaultConsumerGroup      = "hex-api-go.users"
+	defaultConsumerTopics     = "person.updated,user.deactivated"
+	defaultDeadLetterTopic    = "users.consumer.dlq"
+	defaultConsumerRetries    = "500,2000,5000"
+	defaultConsumerTimeout    = 30 * time.Second
+	defaultConsumerProcessors = 1
+)
+
+type ConsumersConfig struct {
+	Brokers           []string
+	Group             string
+	Topics            []string
+	DeadLetterTopic   string
+	RetryTimes        []int
+	Processors        int
+	ProcessingTimeout time.Duration
+}
+
+// ConsumersConfigFromEnv reads KAFKA_BROKERS, USER_CONSUMER_GROUP,
+// USER_CONSUMER_TOPICS (comma separated), USER_CONSUMER_DLQ_TOPIC,
+// USER_CONSUMER_RETRY_MS (comma separated delays between attempts),
+// USER_CONSUMER_PROCESSORS and USER_CONSUMER_PROCESSING_TIMEOUT.
+//
+// The processing timeout covers every retry of a message, so it has to be
+// longer than the sum of the retry delays.
+func ConsumersConfigFromEnv() (*ConsumersConfig, error) {
+	config := &ConsumersConfig{
+		Brokers:           splitList(os.Getenv("KAFKA_BROKERS")),
+		Group:             defaultConsumerGroup,
+		Topics:            splitList(defaultConsumerTopics),
+		DeadLetterTopic:   defaultDeadLetterTopic,
+		Processors:        defaultConsumerProcessors,
+		ProcessingTimeout: defaultConsumerTimeout,
+	}
+
+	if value := os.Getenv("USER_CONSUMER_GROUP"); value != "" {
+		config.Group = value
+	}
+
+	if value := os.Getenv("USER_CONSUMER_TOPICS"); value != "" {
+		config.Topics = splitList(value)
+	}
+
+	if value := os.Getenv("USER_CONSUMER_DLQ_TOPIC"); value != "" {
+		config.DeadLetterTopic = value
+	}
+
+	retries := defaultConsumerRetries
+	if value := os.Getenv("USER_CONSUMER_RETRY_MS"); value != "" {
+		retries = value
+	}
+	for _, value := range splitList(retries) {
+		delay, err := strconv.Atoi(value)
+		if err != nil || delay < 0 {
+			return nil, fmt.Errorf("USER_CONSUMER_RETRY_MS must list non negative milliseconds, got %q", retries)
+		}
+		config.RetryTimes = append(config.RetryTimes, delay)
+	}
+
+	if value := os.Getenv("USER_CONSUMER_PROCESSORS"); value != "" {
+		processors, err := strconv.Atoi(value)
+		if err != nil || processors < 1 {
+			return nil, fmt.Errorf("USER_CONSUMER_PROCESSORS must be a positive integer, got %q", value)
+		}
+		config.Processors = processors
+	}
+
+	if value := os.Getenv("USER_CONSUMER_PROCESSING_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("USER_CONSUMER_PROCESSING_TIMEOUT must be a positive duration, got %q", value)
+		}
+		config.ProcessingTimeout = timeout
+	}
+
+	return config, nil
+}
+
+// Consumers runs one gomes EventDrivenConsumer per configured topic.
+type Consumers struct {
+	config  *ConsumersConfig
+	mu      sync.Mutex
+	running []*endpoint.EventDrivenConsumer
+	wg      sync.WaitGroup
+}
+
+// RegisterConsumers registers the Kafka consumer channels and the dead-letter
+// publisher. Every message goes through the InboundEventTranslator, is retried
+// with the configured delays and lands on the dead-letter topic when the last
+// attempt fails. Without brokers nothing is registered.
+func RegisterConsumers(config *ConsumersConfig, routes map[string]handler.Action) (*Consumers, error) {
+	consumers := &Consumers{config: config}
+	if len(config.Brokers) == 0 {
+		slog.Warn("KAFKA_BROKERS is empty, user consumers are disabled")
+		return consumers, nil
+	}
+
+	err := gomes.AddChannelConnection(kafka.NewConnection(consumersConnectionName, config.Brokers))
+	if err != nil {
+		return nil, err
+	}
+
+	err = gomes.AddPublisherChannel(
+		kafka.NewPublisherChannelAdapterBuilder(consumersConnectionName, config.DeadLetterTopic).
+			WithAsync(false).
+			WithBatchSize(1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	translator := NewInboundEventTranslator(routes)
+	for _, topic := range config.Topics {
+		consumer := kafka.NewConsumerChannelAdapterBuilder(consumersConnectionName, topic, config.Group)
+		consumer.WithBeforeInterceptors(translator)
+		consumer.WithRetryTimes(config.RetryTimes...)
+		consumer.WithDeadLetterChannelName(config.DeadLetterTopic)
+
+		err = gomes.AddConsumerChannel(consumer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return consumers, nil
+}
+
+// Start runs the consumers in background until ctx is done or Stop is
+// called. gomes must be started before.
+func (c *Consumers) Start(ctx context.Context) error {
+	if len(c.config.Brokers) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, topic := range c.config.Topics {
+		consumer, err := gomes.EventDrivenConsumer(topic)
+		if err != nil {
+			return err
+		}
+
+		consumer.
+			WithAmountOfProcessors(c.config.Processors).
+			WithMessageProcessingTimeout(int(c.config.ProcessingTimeout.Milliseconds())).
+			// a failed message already went to the dead-letter topic
+			WithStopOnError(false)
+		c.running = append(c.running, consumer)
+
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			err := consumer.Run(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("[user-consumers] consumer stopped", "topic", topic, "error", err)
+			}
+		}()
+		slog.Info("[user-consumers] consumer started", "topic", topic, "group", c.config.Group)
+	}
+
+	return nil
+}
+
+// Stop stops every running consumer and waits for in-flight messages.
+func (c *Consumers) Stop() {
+	c.mu.Lock()
+	for _, consumer := range c.running {
+		consumer.Stop()
+	}
+	c.running = nil
+	c.mu.Unlock()
+
+	c.wg.Wait()
+}
+
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
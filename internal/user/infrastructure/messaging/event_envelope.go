@@ -0,0 +1,17 @@
+package messaging
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventEnvelope is the JSON document published for every user event. The
+// contract is documented in docs/infrastructure/event_publishing_pattern.md.
+type EventEnvelope struct {
+	EventId     string          `json:"eventId"`
+	EventName   string          `json:"eventName"`
+	AggregateId string          `json:"aggregateId"`
+	OccurredOn  time.Time       `json:"occurredOn"`
+	Version     int             `json:"version"`
+	Payload     json.RawMessage `json:"payload"`
+}
@@ -0,0 +1,85 @@
+package messaging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/jeffersonbrasilino/gomes"
+	"github.com/jeffersonbrasilino/gomes/channel/kafka"
+)
+
+const (
+	defaultEventsTopic       = "users.events"
+	eventsConnectionName     = "user-events-kafka"
+	defaultProducerAcks      = -1
+	defaultProducerBatchSize = 1
+)
+
+type EventsChannelConfig struct {
+	Brokers      []string
+	Topic        string
+	RequiredAcks int
+	BatchSize    int
+}
+
+// EventsChannelConfigFromEnv reads KAFKA_BROKERS (comma separated),
+// USER_EVENTS_TOPIC, KAFKA_PRODUCER_ACKS (-1 all, 0 none, 1 leader) and
+// KAFKA_PRODUCER_BATCH_SIZE.
+//
+// The producer writes synchronously so the outbox only marks what Kafka
+// acknowledged. A sync writer waits for the batch to fill before flushing,
+// which is why the batch size defaults to 1.
+func EventsChannelConfigFromEnv() (*EventsChannelConfig, error) {
+	config := &EventsChannelConfig{
+		Brokers:      splitList(os.Getenv("KAFKA_BROKERS")),
+		Topic:        defaultEventsTopic,
+		RequiredAcks: defaultProducerAcks,
+		BatchSize:    defaultProducerBatchSize,
+	}
+
+	if value := os.Getenv("USER_EVENTS_TOPIC"); value != "" {
+		config.Topic = value
+	}
+
+	if value := os.Getenv("KAFKA_PRODUCER_ACKS"); value != "" {
+		acks, err := strconv.Atoi(value)
+		if err != nil || acks < -1 || acks > 1 {
+			return nil, fmt.Errorf("KAFKA_PRODUCER_ACKS must be -1, 0 or 1, got %q", value)
+		}
+		config.RequiredAcks = acks
+	}
+
+	if value := os.Getenv("KAFKA_PRODUCER_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("KAFKA_PRODUCER_BATCH_SIZE must be a positive integer, got %q", value)
+		}
+		config.BatchSize = size
+	}
+
+	return config, nil
+}
+
+// RegisterEventsChannel registers the gomes publisher channel named after the
+// topic. Without brokers the events stay in process, which keeps local runs
+// and tests free of Kafka.
+func RegisterEventsChannel(config *EventsChannelConfig) error {
+	if len(config.Brokers) == 0 {
+		slog.Warn("KAFKA_BROKERS is empty, user events are published in process only", "channel", config.Topic)
+		return gomes.AddPublisherChannel(NewInProcessChannelBuilder(config.Topic))
+	}
+
+	err := gomes.AddChannelConnection(kafka.NewConnection(eventsConnectionName, config.Brokers))
+	if err != nil {
+		return err
+	}
+
+	return gomes.AddPublisherChannel(
+		kafka.NewPublisherChannelAdapterBuilder(eventsConnectionName, config.Topic).
+			WithAsync(false).
+			WithRequiredAcks(config.RequiredAcks).
+			WithBatchSize(config.BatchSize),
+	)
+}
@@ -0,0 +1,51 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/jeffersonbrasilino/gomes/message/handler"
+)
+
+// ProcessedMessageStore remembers which events each consumer already handled.
+// Claim records the event and runs fn atomically, reporting false without
+// running fn when the event was already claimed.
+type ProcessedMessageStore interface {
+	Claim(ctx context.Context, consumer string, eventId string, fn func(ctx context.Context) error) (bool, error)
+}
+
+type idempotentHandler[T handler.Action, U any] struct {
+	consumer string
+	store    ProcessedMessageStore
+	handler  handler.ActionHandler[T, U]
+}
+
+// Idempotent wraps an action handler so an event redelivered to the consumer
+// is acknowledged without running the action again. Calls that did not come
+// through the InboundEventTranslator carry no event id and always run.
+func Idempotent[T handler.Action, U any](
+	consumer string,
+	store ProcessedMessageStore,
+	next handler.ActionHandler[T, U],
+) handler.ActionHandler[T, U] {
+	return &idempotentHandler[T, U]{
+		consumer: consumer,
+		store:    store,
+		handler:  next,
+	}
+}
+
+func (h *idempotentHandler[T, U]) Handle(ctx context.Context, action T) (U, error) {
+	var output U
+	eventId := eventIdFromContext(ctx)
+	if eventId == "" {
+		return h.handler.Handle(ctx, action)
+	}
+
+	_, err := h.store.Claim(ctx, h.consumer, eventId, func(ctx context.Context) error {
+		var err error
+		output, err = h.handler.Handle(ctx, action)
+		return err
+	})
+
+	return output, err
+}
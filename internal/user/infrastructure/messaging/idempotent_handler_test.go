@@ -0,0 +1,99 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/messaging"
+)
+
+type memoryProcessedStore struct {
+	mu        sync.Mutex
+	processed map[string]bool
+}
+
+func (s *memoryProcessedStore) Claim(ctx context.Context, consumer string, eventId string, fn func(ctx context.Context) error) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := consumer + ":" + eventId
+	if s.processed[key] {
+		return false, nil
+	}
+
+	if err := fn(ctx); err != nil {
+		return true, err
+	}
+	s.processed[key] = true
+	return true, nil
+}
+
+type countingHandler struct {
+	calls int
+	err   error
+}
+
+func (h *countingHandler) Handle(ctx context.Context, data *syncCommand) (any, error) {
+	h.calls++
+	return nil, h.err
+}
+
+// translatedContext returns the context the translator hands to the action.
+func translatedContext(t *testing.T, eventId string) context.Context {
+	t.Helper()
+	msg := newInboundMessage(`{"eventId":"` + eventId + `","eventName":"person.updated","payload":{}}`)
+	translated, err := newTranslator().Handle(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Should translate the message, got: %v", err)
+	}
+	return translated.GetContext()
+}
+
+func TestIdempotentHandle(t *testing.T) {
+	t.Run("Should run the action once per event", func(t *testing.T) {
+		t.Parallel()
+		next := &countingHandler{}
+		idempotent := messaging.Idempotent[*syncCommand, any]("users", &memoryProcessedStore{processed: map[string]bool{}}, next)
+
+		for range 2 {
+			if _, err := idempotent.Handle(translatedContext(t, "event-1"), &syncCommand{}); err != nil {
+				t.Fatalf("Should handle the event, got: %v", err)
+			}
+		}
+
+		if next.calls != 1 {
+			t.Errorf("Should run the action once, got: %d", next.calls)
+		}
+	})
+
+	t.Run("Should run the action again when it failed", func(t *testing.T) {
+		t.Parallel()
+		next := &countingHandler{err: errors.New("database unavailable")}
+		idempotent := messaging.Idempotent[*syncCommand, any]("users", &memoryProcessedStore{processed: map[string]bool{}}, next)
+
+		for range 2 {
+			if _, err := idempotent.Handle(translatedContext(t, "event-1"), &syncCommand{}); err == nil {
+				t.Fatal("Should return the action error")
+			}
+		}
+
+		if next.calls != 2 {
+			t.Errorf("Should run the action twice, got: %d", next.calls)
+		}
+	})
+
+	t.Run("Should always run calls that did not come from a consumer", func(t *testing.T) {
+		t.Parallel()
+		next := &countingHandler{}
+		idempotent := messaging.Idempotent[*syncCommand, any]("users", &memoryProcessedStore{processed: map[string]bool{}}, next)
+
+		for range 2 {
+			_, _ = idempotent.Handle(context.Background(), &syncCommand{})
+		}
+
+		if next.calls != 2 {
+			t.Errorf("Should run the action twice, got: %d", next.calls)
+		}
+	})
+}
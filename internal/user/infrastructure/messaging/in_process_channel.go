@@ -0,0 +1,37 @@
+package messaging
+
+import (
+	"github.com/jeffersonbrasilino/gomes/container"
+	"github.com/jeffersonbrasilino/gomes/message"
+	"github.com/jeffersonbrasilino/gomes/message/adapter"
+	"github.com/jeffersonbrasilino/gomes/message/channel"
+	"github.com/jeffersonbrasilino/gomes/message/endpoint"
+)
+
+// InProcessChannelBuilder registers a gomes publisher channel that fans
+// messages out to in-process subscribers instead of a broker.
+type InProcessChannelBuilder struct {
+	name        string
+	subscribers []func(m *message.Message)
+}
+
+func NewInProcessChannelBuilder(name string, subscribers ...func(m *message.Message)) *InProcessChannelBuilder {
+	return &InProcessChannelBuilder{
+		name:        name,
+		subscribers: subscribers,
+	}
+}
+
+func (b *InProcessChannelBuilder) ReferenceName() string {
+	return b.name
+}
+
+func (b *InProcessChannelBuilder) Build(
+	container container.Container[any, any],
+) (endpoint.OutboundChannelAdapter, error) {
+	pubSub := channel.NewPubSubChannel(b.name)
+	// Subscribing without callbacks still drains the channel, so publishing
+	// never blocks while nobody listens.
+	pubSub.Subscribe(b.subscribers...)
+	return adapter.NewOutboundChannelAdapter(pubSub, ""), nil
+}
@@ -0,0 +1,65 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jeffersonbrasilino/gomes/message"
+	"github.com/jeffersonbrasilino/gomes/message/handler"
+)
+
+type eventIdContextKey struct{}
+
+// InboundEventTranslator is a consumer before-interceptor that unwraps the
+// event envelope, routes it to the action registered for its event name and
+// hands the event payload to that action.
+type InboundEventTranslator struct {
+	routes map[string]handler.Action
+}
+
+func NewInboundEventTranslator(routes map[string]handler.Action) *InboundEventTranslator {
+	return &InboundEventTranslator{
+		routes: routes,
+	}
+}
+
+func (t *InboundEventTranslator) Handle(ctx context.Context, msg *message.Message) (*message.Message, error) {
+	body, ok := msg.GetPayload().([]byte)
+	if !ok {
+		return nil, fmt.Errorf("[inbound-event-translator] unexpected payload type %T", msg.GetPayload())
+	}
+
+	var envelope EventEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("[inbound-event-translator] invalid event envelope: %w", err)
+	}
+
+	if envelope.EventId == "" {
+		return nil, fmt.Errorf("[inbound-event-translator] event %q has no eventId", envelope.EventName)
+	}
+
+	action, ok := t.routes[envelope.EventName]
+	if !ok {
+		return nil, fmt.Errorf("[inbound-event-translator] no action registered for event %q", envelope.EventName)
+	}
+
+	messageCtx := msg.GetContext()
+	if messageCtx == nil {
+		messageCtx = ctx
+	}
+
+	// The builder does not carry context nor reply channel over, and the
+	// gateway waits for the action reply on that channel.
+	return message.NewMessageBuilderFromMessage(msg).
+		WithRoute(action.Name()).
+		WithPayload([]byte(envelope.Payload)).
+		WithContext(context.WithValue(messageCtx, eventIdContextKey{}, envelope.EventId)).
+		WithInternalReplyChannel(msg.GetInternalReplyChannel()).
+		Build(), nil
+}
+
+func eventIdFromContext(ctx context.Context) string {
+	eventId, _ := ctx.Value(eventIdContextKey{}).(string)
+	return eventId
+}
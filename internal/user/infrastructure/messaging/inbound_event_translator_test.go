@@ -0,0 +1,75 @@
+package messaging_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jeffersonbrasilino/gomes/message"
+	"github.com/jeffersonbrasilino/gomes/message/channel"
+	"github.com/jeffersonbrasilino/gomes/message/handler"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/messaging"
+)
+
+type syncCommand struct {
+	PersonId string `json:"personId"`
+}
+
+func (c *syncCommand) Name() string {
+	return "syncPerson"
+}
+
+func newInboundMessage(body string) *message.Message {
+	return message.NewMessageBuilder().
+		WithContext(context.Background()).
+		WithPayload([]byte(body)).
+		WithInternalReplyChannel(channel.NewPointToPointChannel("reply")).
+		Build()
+}
+
+func newTranslator() *messaging.InboundEventTranslator {
+	return messaging.NewInboundEventTranslator(map[string]handler.Action{
+		"person.updated": &syncCommand{},
+	})
+}
+
+func TestInboundEventTranslatorHandle(t *testing.T) {
+	t.Run("Should route the event payload to the registered action", func(t *testing.T) {
+		t.Parallel()
+		msg := newInboundMessage(`{"eventId":"event-1","eventName":"person.updated","payload":{"personId":"person-1"}}`)
+
+		translated, err := newTranslator().Handle(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("Should translate the message, got: %v", err)
+		}
+
+		if translated.GetHeader().Get(message.HeaderRoute) != "syncPerson" {
+			t.Errorf("Should route to syncPerson, got: %s", translated.GetHeader().Get(message.HeaderRoute))
+		}
+
+		if string(translated.GetPayload().([]byte)) != `{"personId":"person-1"}` {
+			t.Errorf("Should carry only the event payload, got: %s", translated.GetPayload())
+		}
+
+		if translated.GetInternalReplyChannel() != msg.GetInternalReplyChannel() {
+			t.Error("Should keep the internal reply channel")
+		}
+	})
+
+	cases := []struct {
+		description string
+		body        string
+	}{
+		{description: "Should fail when the payload is not an envelope", body: `not json`},
+		{description: "Should fail when the event has no id", body: `{"eventName":"person.updated","payload":{}}`},
+		{description: "Should fail when no action handles the event", body: `{"eventId":"event-1","eventName":"person.deleted","payload":{}}`},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			translated, err := newTranslator().Handle(context.Background(), newInboundMessage(c.body))
+			if err == nil || translated != nil {
+				t.Errorf("Should return an error, got: %v, %v", translated, err)
+			}
+		})
+	}
+}
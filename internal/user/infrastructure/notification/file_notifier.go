@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+// FileNotifier appends every message as a JSON line to a file, so local and
+// end to end runs can read the codes back.
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+type fileMessage struct {
+	Kind      string    `json:"kind"`
+	UserId    string    `json:"userId"`
+	To        string    `json:"to"`
+	Code      string    `json:"code,omitempty"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	SentAt    time.Time `json:"sentAt"`
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) SendVerificationCode(ctx context.Context, message *contract.VerificationMessage) error {
+	return n.append(fileMessage{
+		Kind:      "verificationCode",
+		UserId:    message.UserId,
+		To:        message.To,
+		Code:      message.Code,
+		ExpiresAt: message.ExpiresAt.UTC(),
+		SentAt:    time.Now().UTC(),
+	})
+}
+
+func (n *FileNotifier) SendPasswordReset(ctx context.Context, message *contract.PasswordResetMessage) error {
+	return n.append(fileMessage{
+		Kind:      "passwordReset",
+		UserId:    message.UserId,
+		To:        message.To,
+		Token:     message.Token,
+		ExpiresAt: message.ExpiresAt.UTC(),
+		SentAt:    time.Now().UTC(),
+	})
+}
+
+func (n *FileNotifier) append(message fileMessage) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return ddgo.NewInternalError(fmt.Sprintf("Error when encoding notification: %s", err.Error()))
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return ddgo.NewDependencyError(fmt.Sprintf("Error when opening notification file: %s", err.Error()))
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return ddgo.NewDependencyError(fmt.Sprintf("Error when writing notification file: %s", err.Error()))
+	}
+	return nil
+}
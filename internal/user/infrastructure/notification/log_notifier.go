@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+// LogNotifier writes messages to the application log instead of sending
+// them, for local runs only. The log pipeline redacts the recipient and the
+// codes, use the FileNotifier to read them.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) SendVerificationCode(ctx context.Context, message *contract.VerificationMessage) error {
+	slog.InfoContext(ctx, "[notifier] verification code",
+		"userId", message.UserId,
+		"to", message.To,
+		"code", message.Code,
+		"expiresAt", message.ExpiresAt,
+	)
+	return nil
+}
+
+func (n *LogNotifier) SendPasswordReset(ctx context.Context, message *contract.PasswordResetMessage) error {
+	slog.InfoContext(ctx, "[notifier] password reset",
+		"userId", message.UserId,
+		"to", message.To,
+		"token", message.Token,
+		"expiresAt", message.ExpiresAt,
+	)
+	return nil
+}
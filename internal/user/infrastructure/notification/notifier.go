@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
+)
+
+// NewNotifierFromEnv picks the adapter named by USER_NOTIFIER, log by
+// default. Both keep the codes on the machine, so they only run when APP_ENV
+// is local.
+func NewNotifierFromEnv() (contract.Notifier, error) {
+	kind := os.Getenv("USER_NOTIFIER")
+	if env := os.Getenv("APP_ENV"); env != "local" {
+		return nil, fmt.Errorf("USER_NOTIFIER %q only runs with APP_ENV=local, got %q", kind, env)
+	}
+
+	switch kind {
+	case "", "log":
+		return NewLogNotifier(), nil
+	case "file":
+		path := os.Getenv("USER_NOTIFIER_FILE_PATH")
+		if path == "" {
+			return nil, fmt.Errorf("USER_NOTIFIER_FILE_PATH is required by the file notifier")
+		}
+		return NewFileNotifier(path), nil
+	default:
+		return nil, fmt.Errorf("USER_NOTIFIER must be log or file, got %q", kind)
+	}
+}
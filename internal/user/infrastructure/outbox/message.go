@@ -0,0 +1,18 @@
+package outbox
+
+import "time"
+
+// Message is a domain event waiting in the outbox to be published.
+type Message struct {
+	ID            uint
+	Uuid          string
+	EventName     string
+	AggregateId   string
+	Payload       []byte
+	OccurredOn    time.Time
+	Version       int
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	SentAt        *time.Time
+}
@@ -0,0 +1,169 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jeffersonbrasilino/gomes/message"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/messaging"
+)
+
+const (
+	HeaderEventId     = "eventId"
+	HeaderAggregateId = "aggregateId"
+)
+
+// Store keeps the messages of an aggregate in order: Claim leaves a message
+// out while an older one of the same aggregate is not sent.
+type Store interface {
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*Message, error)
+	MarkSent(ctx context.Context, message *Message) error
+	MarkFailed(ctx context.Context, message *Message) error
+	DeleteSent(ctx context.Context, sentBefore time.Time, limit int) (int, error)
+}
+
+// Publisher is satisfied by the gomes *bus.EventBus of the events channel.
+type Publisher interface {
+	PublishRaw(ctx context.Context, route string, payload any, headers map[string]string) error
+}
+
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Retention is how long sent messages are kept before being deleted.
+	Retention       time.Duration
+	CleanupInterval time.Duration
+}
+
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval:    time.Second,
+		BatchSize:       100,
+		Lease:           30 * time.Second,
+		BaseBackoff:     time.Second,
+		MaxBackoff:      5 * time.Minute,
+		Retention:       7 * 24 * time.Hour,
+		CleanupInterval: time.Hour,
+	}
+}
+
+type Relay struct {
+	store     Store
+	publisher Publisher
+	config    RelayConfig
+}
+
+func NewRelay(store Store, publisher Publisher, config RelayConfig) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		config:    config,
+	}
+}
+
+// Start polls the outbox until ctx is cancelled.
+func (r *Relay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.config.PollInterval)
+		defer ticker.Stop()
+		cleanup := time.NewTicker(r.config.CleanupInterval)
+		defer cleanup.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.RelayPending(ctx); err != nil {
+					slog.Error("[outbox-relay]", "error", err)
+				}
+			case <-cleanup.C:
+				if _, err := r.DeleteSent(ctx); err != nil {
+					slog.Error("[outbox-relay]", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// RelayPending publishes one batch of due messages and returns how many were
+// sent. Failed messages are rescheduled with exponential backoff and the
+// later messages of their aggregate in the batch are skipped, so none is
+// published before it.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	messages, err := r.store.Claim(ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	failed := map[string]bool{}
+	for _, pending := range messages {
+		if failed[pending.AggregateId] {
+			continue
+		}
+
+		err := r.publisher.PublishRaw(ctx, pending.EventName, envelope(pending), map[string]string{
+			HeaderEventId:     pending.Uuid,
+			HeaderAggregateId: pending.AggregateId,
+			// Kafka keys messages by correlation id; using the aggregate id
+			// keeps every event of a user on the same partition, in order.
+			message.HeaderCorrelationId: pending.AggregateId,
+		})
+
+		if err != nil {
+			pending.Attempts++
+			pending.NextAttemptAt = time.Now().UTC().Add(r.backoff(pending.Attempts))
+			pending.LastError = err.Error()
+			failed[pending.AggregateId] = true
+			if err := r.store.MarkFailed(ctx, pending); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if err := r.store.MarkSent(ctx, pending); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// DeleteSent removes, in batches, the messages sent longer than the retention
+// ago and returns how many were deleted.
+func (r *Relay) DeleteSent(ctx context.Context) (int, error) {
+	sentBefore := time.Now().UTC().Add(-r.config.Retention)
+	total := 0
+	for {
+		deleted, err := r.store.DeleteSent(ctx, sentBefore, r.config.BatchSize)
+		total += deleted
+		if err != nil || deleted < r.config.BatchSize {
+			return total, err
+		}
+	}
+}
+
+func envelope(pending *Message) *messaging.EventEnvelope {
+	return &messaging.EventEnvelope{
+		EventId:     pending.Uuid,
+		EventName:   pending.EventName,
+		AggregateId: pending.AggregateId,
+		OccurredOn:  pending.OccurredOn.UTC(),
+		Version:     pending.Version,
+		Payload:     pending.Payload,
+	}
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.config.MaxBackoff)
+}
@@ -0,0 +1,294 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jeffersonbrasilino/gomes/message"
+	"github.com/jeffersonbrasilino/gomes/message/channel"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/messaging"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/outbox"
+)
+
+type memoryStore struct {
+	mu       sync.Mutex
+	messages []*outbox.Message
+}
+
+func (s *memoryStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]*outbox.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	claimed := make([]*outbox.Message, 0, limit)
+	for _, m := range s.messages {
+		if len(claimed) == limit {
+			break
+		}
+		if m.SentAt == nil && !m.NextAttemptAt.After(now) {
+			m.NextAttemptAt = now.Add(lease)
+			copied := *m
+			claimed = append(claimed, &copied)
+		}
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) MarkSent(ctx context.Context, message *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.find(message.ID).SentAt = &now
+	return nil
+}
+
+func (s *memoryStore) MarkFailed(ctx context.Context, message *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.find(message.ID)
+	stored.Attempts = message.Attempts
+	stored.NextAttemptAt = message.NextAttemptAt
+	stored.LastError = message.LastError
+	return nil
+}
+
+func (s *memoryStore) DeleteSent(ctx context.Context, sentBefore time.Time, limit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	s.messages = slices.DeleteFunc(s.messages, func(m *outbox.Message) bool {
+		if deleted < limit && m.SentAt != nil && m.SentAt.Before(sentBefore) {
+			deleted++
+			return true
+		}
+		return false
+	})
+	return deleted, nil
+}
+
+func (s *memoryStore) find(id uint) *outbox.Message {
+	for _, m := range s.messages {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) get(id uint) outbox.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.find(id)
+}
+
+// channelPublisher stands in for the gomes event bus, handing every message
+// to an in-memory channel.
+type channelPublisher struct {
+	channel *channel.PointToPointChannel
+}
+
+func (p *channelPublisher) PublishRaw(ctx context.Context, route string, payload any, headers map[string]string) error {
+	builder, err := message.NewMessageBuilderFromHeaders(headers)
+	if err != nil {
+		return err
+	}
+	return p.channel.Send(ctx, builder.WithRoute(route).WithPayload(payload).Build())
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) PublishRaw(ctx context.Context, route string, payload any, headers map[string]string) error {
+	return errors.New("broker unavailable")
+}
+
+// recordingPublisher fails the events in fail and records the others.
+type recordingPublisher struct {
+	fail      map[string]bool
+	published []string
+}
+
+func (p *recordingPublisher) PublishRaw(ctx context.Context, route string, payload any, headers map[string]string) error {
+	if p.fail[headers[outbox.HeaderEventId]] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, headers[outbox.HeaderEventId])
+	return nil
+}
+
+func newMessage(id uint, uuid string) *outbox.Message {
+	occurredOn := time.Now().Add(-time.Second)
+	return &outbox.Message{
+		ID:            id,
+		Uuid:          uuid,
+		EventName:     "userCreated",
+		AggregateId:   "3f2b1c3e-2a5e-4c1b-9b1a-1c2d3e4f5a6b",
+		Payload:       []byte(`{"userId":"3f2b1c3e-2a5e-4c1b-9b1a-1c2d3e4f5a6b"}`),
+		OccurredOn:    occurredOn,
+		Version:       1,
+		NextAttemptAt: occurredOn,
+	}
+}
+
+func testConfig() outbox.RelayConfig {
+	return outbox.RelayConfig{
+		PollInterval:    10 * time.Millisecond,
+		BatchSize:       10,
+		Lease:           time.Minute,
+		BaseBackoff:     time.Second,
+		MaxBackoff:      3 * time.Second,
+		Retention:       time.Hour,
+		CleanupInterval: time.Hour,
+	}
+}
+
+func TestRelayStart(t *testing.T) {
+	t.Run("Should publish pending messages and mark them as sent", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{messages: []*outbox.Message{newMessage(1, "event-1"), newMessage(2, "event-2")}}
+		events := channel.NewPointToPointChannel("users.events")
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		outbox.NewRelay(store, &channelPublisher{channel: events}, testConfig()).Start(ctx)
+
+		for _, expected := range []string{"event-1", "event-2"} {
+			msg, err := events.Receive(ctx)
+			if err != nil {
+				t.Fatalf("Should receive %s, got: %v", expected, err)
+			}
+
+			if msg.GetHeader().Get(message.HeaderRoute) != "userCreated" {
+				t.Errorf("Should route by event name, got: %s", msg.GetHeader().Get(message.HeaderRoute))
+			}
+
+			if msg.GetHeader().Get(outbox.HeaderEventId) != expected {
+				t.Errorf("Should receive %s, got: %s", expected, msg.GetHeader().Get(outbox.HeaderEventId))
+			}
+
+			if msg.GetHeader().Get(message.HeaderCorrelationId) != "3f2b1c3e-2a5e-4c1b-9b1a-1c2d3e4f5a6b" {
+				t.Errorf("Should key the message by aggregate id, got: %s", msg.GetHeader().Get(message.HeaderCorrelationId))
+			}
+
+			envelope, ok := msg.GetPayload().(*messaging.EventEnvelope)
+			if !ok {
+				t.Fatalf("Should publish an event envelope, got: %T", msg.GetPayload())
+			}
+
+			if envelope.EventId != expected || envelope.EventName != "userCreated" || envelope.Version != 1 {
+				t.Errorf("Should describe the event in the envelope, got: %+v", envelope)
+			}
+
+			if string(envelope.Payload) != `{"userId":"3f2b1c3e-2a5e-4c1b-9b1a-1c2d3e4f5a6b"}` {
+				t.Errorf("Should carry the event payload untouched, got: %s", envelope.Payload)
+			}
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for store.get(2).SentAt == nil && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		if store.get(1).SentAt == nil || store.get(2).SentAt == nil {
+			t.Error("Should mark every published message as sent")
+		}
+	})
+}
+
+func TestRelayPending(t *testing.T) {
+	t.Run("Should reschedule failed messages with exponential backoff", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{messages: []*outbox.Message{newMessage(1, "event-1")}}
+		relay := outbox.NewRelay(store, failingPublisher{}, testConfig())
+
+		expectedDelays := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+		for attempt, expectedDelay := range expectedDelays {
+			before := time.Now()
+			sent, err := relay.RelayPending(context.Background())
+			if err != nil || sent != 0 {
+				t.Fatalf("Should send nothing without error, got: %d, %v", sent, err)
+			}
+
+			stored := store.get(1)
+			if stored.Attempts != attempt+1 {
+				t.Errorf("Should count %d attempts, got: %d", attempt+1, stored.Attempts)
+			}
+
+			if stored.LastError != "broker unavailable" {
+				t.Errorf("Should keep the last error, got: %s", stored.LastError)
+			}
+
+			delay := stored.NextAttemptAt.Sub(before)
+			if delay < expectedDelay || delay > expectedDelay+time.Second {
+				t.Errorf("Should retry in about %s, got: %s", expectedDelay, delay)
+			}
+
+			// make the message due again for the next round
+			store.mu.Lock()
+			store.find(1).NextAttemptAt = time.Now()
+			store.mu.Unlock()
+		}
+	})
+
+	t.Run("Should skip messages that are not due yet", func(t *testing.T) {
+		t.Parallel()
+		pending := newMessage(1, "event-1")
+		pending.NextAttemptAt = time.Now().Add(time.Hour)
+		store := &memoryStore{messages: []*outbox.Message{pending}}
+		events := channel.NewPointToPointChannel("users.events")
+
+		sent, err := outbox.NewRelay(store, &channelPublisher{channel: events}, testConfig()).RelayPending(context.Background())
+		if err != nil || sent != 0 {
+			t.Errorf("Should send nothing, got: %d, %v", sent, err)
+		}
+	})
+	t.Run("Should not publish an aggregate message after one that failed", func(t *testing.T) {
+		t.Parallel()
+		other := newMessage(3, "event-3")
+		other.AggregateId = "7d1e2f3a-4b5c-4d6e-8f7a-9b0c1d2e3f4a"
+		store := &memoryStore{messages: []*outbox.Message{newMessage(1, "event-1"), newMessage(2, "event-2"), other}}
+		publisher := &recordingPublisher{fail: map[string]bool{"event-1": true}}
+
+		sent, err := outbox.NewRelay(store, publisher, testConfig()).RelayPending(context.Background())
+		if err != nil || sent != 1 {
+			t.Fatalf("Should send one message, got: %d, %v", sent, err)
+		}
+
+		if !slices.Equal(publisher.published, []string{"event-3"}) {
+			t.Errorf("Should only publish the other aggregate, got: %v", publisher.published)
+		}
+
+		if store.get(2).SentAt != nil || store.get(2).Attempts != 0 {
+			t.Errorf("Should leave the next message of the aggregate untouched, got: %+v", store.get(2))
+		}
+	})
+}
+
+func TestRelayDeleteSent(t *testing.T) {
+	t.Run("Should delete the messages sent before the retention", func(t *testing.T) {
+		t.Parallel()
+		old := time.Now().Add(-2 * time.Hour)
+		recent := time.Now()
+		messages := []*outbox.Message{newMessage(1, "event-1"), newMessage(2, "event-2"), newMessage(3, "event-3")}
+		messages[0].SentAt = &old
+		messages[1].SentAt = &recent
+		store := &memoryStore{messages: messages}
+
+		config := testConfig()
+		config.BatchSize = 1
+		deleted, err := outbox.NewRelay(store, failingPublisher{}, config).DeleteSent(context.Background())
+		if err != nil || deleted != 1 {
+			t.Fatalf("Should delete one message, got: %d, %v", deleted, err)
+		}
+
+		if len(store.messages) != 2 || store.messages[0].ID != 2 || store.messages[1].ID != 3 {
+			t.Errorf("Should keep the recent and unsent messages, got: %d messages", len(store.messages))
+		}
+	})
+}
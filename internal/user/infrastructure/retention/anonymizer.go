@@ -0,0 +1,75 @@
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Store interface {
+	AnonymizeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int, error)
+}
+
+type AnonymizerConfig struct {
+	// Retention is how long deleted users keep their personal data.
+	Retention    time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+func DefaultAnonymizerConfig() AnonymizerConfig {
+	return AnonymizerConfig{
+		Retention:    30 * 24 * time.Hour,
+		PollInterval: time.Hour,
+		BatchSize:    100,
+	}
+}
+
+// Anonymizer erases the personal data of users deleted longer than the
+// retention period.
+type Anonymizer struct {
+	store  Store
+	config AnonymizerConfig
+	now    func() time.Time
+}
+
+func NewAnonymizer(store Store, config AnonymizerConfig) *Anonymizer {
+	return &Anonymizer{
+		store:  store,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Start anonymizes expired users until ctx is cancelled.
+func (a *Anonymizer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(a.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := a.AnonymizeExpired(ctx); err != nil {
+					slog.Error("[user-retention]", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// AnonymizeExpired works through every expired user in batches and returns
+// how many were anonymized.
+func (a *Anonymizer) AnonymizeExpired(ctx context.Context) (int, error) {
+	deletedBefore := a.now().Add(-a.config.Retention)
+	total := 0
+	for {
+		anonymized, err := a.store.AnonymizeDeleted(ctx, deletedBefore, a.config.BatchSize)
+		total += anonymized
+		if err != nil || anonymized < a.config.BatchSize {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/retention"
+)
+
+type memoryStore struct {
+	deletedAt []time.Time
+	failAfter int
+	calls     int
+}
+
+func (s *memoryStore) AnonymizeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	s.calls++
+	if s.failAfter > 0 && s.calls > s.failAfter {
+		return 0, errors.New("connection lost")
+	}
+
+	kept := make([]time.Time, 0, len(s.deletedAt))
+	anonymized := 0
+	for _, deletedAt := range s.deletedAt {
+		if anonymized < limit && deletedAt.Before(deletedBefore) {
+			anonymized++
+			continue
+		}
+		kept = append(kept, deletedAt)
+	}
+	s.deletedAt = kept
+	return anonymized, nil
+}
+
+func TestAnonymizeExpired(t *testing.T) {
+	now := time.Now()
+	config := retention.AnonymizerConfig{Retention: 24 * time.Hour, PollInterval: time.Hour, BatchSize: 2}
+
+	t.Run("Should anonymize every expired user in batches", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{deletedAt: []time.Time{
+			now.Add(-72 * time.Hour),
+			now.Add(-48 * time.Hour),
+			now.Add(-25 * time.Hour),
+			now.Add(-time.Hour),
+		}}
+
+		anonymized, err := retention.NewAnonymizer(store, config).AnonymizeExpired(context.Background())
+		if err != nil {
+			t.Fatalf("Should anonymize, got: %v", err)
+		}
+
+		if anonymized != 3 {
+			t.Errorf("Should anonymize 3 users, got: %d", anonymized)
+		}
+
+		if len(store.deletedAt) != 1 {
+			t.Errorf("Should keep the user inside the retention period, got: %d", len(store.deletedAt))
+		}
+	})
+
+	t.Run("Should return the users anonymized before an error", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{failAfter: 1, deletedAt: []time.Time{
+			now.Add(-72 * time.Hour),
+			now.Add(-48 * time.Hour),
+			now.Add(-48 * time.Hour),
+		}}
+
+		anonymized, err := retention.NewAnonymizer(store, config).AnonymizeExpired(context.Background())
+		if err == nil {
+			t.Fatal("Should return the store error")
+		}
+
+		if anonymized != 2 {
+			t.Errorf("Should report 2 users, got: %d", anonymized)
+		}
+	})
+}
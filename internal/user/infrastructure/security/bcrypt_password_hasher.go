@@ -0,0 +1,31 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type BcryptPasswordHasher struct {
+	cost int
+}
+
+func NewBcryptPasswordHasher(cost int) *BcryptPasswordHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptPasswordHasher{cost: cost}
+}
+
+func (h *BcryptPasswordHasher) Hash(plain string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), h.cost)
+	if err != nil {
+		return "", ddgo.NewInternalError(fmt.Sprintf("Error to hash password: %s", err.Error()))
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptPasswordHasher) Verify(hash string, plain string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)) == nil
+}
@@ -0,0 +1,54 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/security"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptPasswordHasher(t *testing.T) {
+	t.Run("Should verify the password it hashed", func(t *testing.T) {
+		t.Parallel()
+		hasher := security.NewBcryptPasswordHasher(bcrypt.MinCost)
+		hash, err := hasher.Hash("s3cret-Password")
+		if err != nil {
+			t.Fatalf("Should hash, got: %v", err)
+		}
+
+		if hash == "s3cret-Password" {
+			t.Error("Should not return the plain password")
+		}
+		if !hasher.Verify(hash, "s3cret-Password") {
+			t.Error("Should verify the right password")
+		}
+		if hasher.Verify(hash, "wrong-Password") {
+			t.Error("Should not verify a wrong password")
+		}
+	})
+
+	var cases = []struct {
+		description string
+		cost        int
+		expected    int
+	}{
+		{"Should keep a valid cost", bcrypt.MinCost, bcrypt.MinCost},
+		{"Should fall back to the default cost below the minimum", bcrypt.MinCost - 1, bcrypt.DefaultCost},
+		{"Should fall back to the default cost above the maximum", bcrypt.MaxCost + 1, bcrypt.DefaultCost},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			hash, err := security.NewBcryptPasswordHasher(c.cost).Hash("s3cret-Password")
+			if err != nil {
+				t.Fatalf("Should hash, got: %v", err)
+			}
+
+			cost, _ := bcrypt.Cost([]byte(hash))
+			if cost != c.expected {
+				t.Errorf("Should hash with cost %d, got: %d", c.expected, cost)
+			}
+		})
+	}
+}
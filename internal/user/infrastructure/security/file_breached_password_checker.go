@@ -0,0 +1,63 @@
+package security
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var sha1Line = regexp.MustCompile(`^[0-9A-Fa-f]{40}(:\d+)?$`)
+
+// FileBreachedPasswordChecker keeps the SHA-1 of every entry of a local breach
+// list in memory. Lines may be plain passwords or HIBP style "SHA1:count".
+type FileBreachedPasswordChecker struct {
+	hashes map[string]struct{}
+}
+
+func NewFileBreachedPasswordChecker(path string) (*FileBreachedPasswordChecker, error) {
+	checker := &FileBreachedPasswordChecker{hashes: map[string]struct{}{}}
+	if path == "" {
+		return checker, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if sha1Line.MatchString(line) {
+			checker.hashes[strings.ToUpper(line[:40])] = struct{}{}
+			continue
+		}
+		checker.hashes[sha1Hex(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read breached password list: %w", err)
+	}
+
+	return checker, nil
+}
+
+func (c *FileBreachedPasswordChecker) IsBreached(ctx context.Context, plain string) (bool, error) {
+	_, found := c.hashes[sha1Hex(plain)]
+	return found, nil
+}
+
+func sha1Hex(value string) string {
+	sum := sha1.Sum([]byte(value))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
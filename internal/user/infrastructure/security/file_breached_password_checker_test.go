@@ -0,0 +1,69 @@
+package security_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/security"
+)
+
+func TestFileBreachedPasswordChecker(t *testing.T) {
+	// 5BAA61E4... is the SHA-1 of "password", 7C4A8D09... the one of "123456"
+	list := "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8:3861493\n" +
+		"7C4A8D09CA3762AF61E59520943DC26494F8941B\n" +
+		"\n" +
+		"  letmein  \n"
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(list), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	checker, err := security.NewFileBreachedPasswordChecker(path)
+	if err != nil {
+		t.Fatalf("Should load the list, got: %v", err)
+	}
+
+	var cases = []struct {
+		description string
+		password    string
+		expected    bool
+	}{
+		{"Should find a HIBP style SHA-1 with count, whatever the case", "password", true},
+		{"Should find a bare SHA-1", "123456", true},
+		{"Should find a plain password, trimmed", "letmein", true},
+		{"Should not find a password missing from the list", "c0rrect-Horse-battery", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			breached, err := checker.IsBreached(context.Background(), c.password)
+			if err != nil {
+				t.Fatalf("Should check, got: %v", err)
+			}
+			if breached != c.expected {
+				t.Errorf("Should return %v, got: %v", c.expected, breached)
+			}
+		})
+	}
+
+	t.Run("Should accept every password without a list", func(t *testing.T) {
+		t.Parallel()
+		empty, err := security.NewFileBreachedPasswordChecker("")
+		if err != nil {
+			t.Fatalf("Should build, got: %v", err)
+		}
+		if breached, _ := empty.IsBreached(context.Background(), "password"); breached {
+			t.Error("Should not report a breach")
+		}
+	})
+
+	t.Run("Should fail when the list cannot be opened", func(t *testing.T) {
+		t.Parallel()
+		if _, err := security.NewFileBreachedPasswordChecker(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+			t.Error("Should return an error")
+		}
+	})
+}
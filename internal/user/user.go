@@ -3,22 +3,78 @@ package user
 import (
 	"context"
 	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jeffersonbrasilino/gomes"
-	_ "github.com/jeffersonbrasilino/gomes/channel/kafka"
+	"github.com/jeffersonbrasilino/gomes/message/handler"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/addgroupuser"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/auth"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/bootstrapadmins"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/changepassword"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/changeusername"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/creategroup"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/createuser"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/deactivateuser"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/deletegroup"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/deleteuser"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/grantgrouppermission"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/reactivateuser"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/removegroupuser"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/renamegroup"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/requestpasswordreset"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/resendverification"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/resetpassword"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/revokedevice"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/revokegrouppermission"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/revokeotherdevices"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/setusermaingroup"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/syncperson"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/updateuserprofile"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/command/verifyemail"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/password"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/getuser"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listdevices"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/application/query/listusers"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/domain/contract"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/database"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/datasource"
 	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/http"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/messaging"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/notification"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/outbox"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/retention"
+	"github.com/jeffersonbrasilino/hex-api-go/internal/user/infrastructure/security"
+	pkgauth "github.com/jeffersonbrasilino/hex-api-go/pkg/auth"
+	pkghttp "github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/observability"
 	"gorm.io/gorm"
 )
 
 type userModule struct {
-	httpLib    *gin.Engine
-	db         *gorm.DB
-	repository contract.UserRepository
-	dataSource contract.UserDataSource
+	httpLib         *gin.Engine
+	db              *gorm.DB
+	repository      contract.UserRepository
+	groups          contract.GroupRepository
+	persons         contract.PersonRepository
+	dataSource      contract.UserDataSource
+	passwordHasher  contract.PasswordHasher
+	breachedChecker contract.BreachedPasswordChecker
+	agePolicy       *domain.AgePolicy
+	verification    *domain.VerificationPolicy
+	notifier        contract.Notifier
+	passwordReset   time.Duration
+	refreshTokens   contract.RefreshTokenRepository
+	accessTokens    *pkgauth.JWT
+	refreshTokenTTL time.Duration
+	guard           *pkghttp.AuthGuard
+	processed       messaging.ProcessedMessageStore
+	consumerGroup   string
+	consumers       *messaging.Consumers
 }
 
 func NewUserModule(httpLib *gin.Engine, db *gorm.DB) *userModule {
@@ -30,18 +86,254 @@ func NewUserModule(httpLib *gin.Engine, db *gorm.DB) *userModule {
 
 func (u *userModule) Register(ctx context.Context) error {
 	u.repository = database.NewGormUserRepository(u.db)
+	u.groups = database.NewGormGroupRepository(u.db)
+	u.persons = database.NewGormPersonRepository(u.db)
+	u.processed = database.NewGormProcessedMessageRepository(u.db)
+
+	dataSource, err := datasource.NewRegistryFromEnv(datasource.NewLocalGateway(u.repository))
+	if err != nil {
+		return err
+	}
+	u.dataSource = dataSource
+
+	bcryptCost, _ := strconv.Atoi(os.Getenv("PASSWORD_BCRYPT_COST"))
+	u.passwordHasher = security.NewBcryptPasswordHasher(bcryptCost)
+
+	breachedChecker, err := security.NewFileBreachedPasswordChecker(os.Getenv("PASSWORD_BREACHED_LIST_PATH"))
+	if err != nil {
+		return err
+	}
+	u.breachedChecker = breachedChecker
+
+	u.agePolicy = domain.DefaultAgePolicy()
+	if value := os.Getenv("USER_MINIMUM_AGE"); value != "" {
+		u.agePolicy.MinimumAge, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	u.verification, err = verificationPolicyFromEnv()
+	if err != nil {
+		return err
+	}
+
+	u.notifier, err = notification.NewNotifierFromEnv()
+	if err != nil {
+		return err
+	}
+
+	u.passwordReset = domain.DefaultPasswordResetTTL
+	if value := os.Getenv("USER_PASSWORD_RESET_TTL"); value != "" {
+		u.passwordReset, err = time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	u.refreshTokens = database.NewGormRefreshTokenRepository(u.db)
+	u.accessTokens, err = pkgauth.NewJWTFromEnv()
+	if err != nil {
+		return err
+	}
+
+	u.refreshTokenTTL = 30 * 24 * time.Hour
+	if value := os.Getenv("AUTH_REFRESH_TOKEN_TTL"); value != "" {
+		u.refreshTokenTTL, err = time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	permissionCacheTTL := time.Minute
+	if value := os.Getenv("AUTH_PERMISSION_CACHE_TTL"); value != "" {
+		permissionCacheTTL, err = time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+	}
+	u.guard = pkghttp.NewAuthGuard(u.accessTokens, database.NewGormPermissionResolver(u.db), permissionCacheTTL)
+
+	eventsChannel, err := messaging.EventsChannelConfigFromEnv()
+	if err != nil {
+		return err
+	}
+
+	err = messaging.RegisterEventsChannel(eventsChannel)
+	if err != nil {
+		return err
+	}
+
+	eventBus, err := gomes.EventBusByChannel(eventsChannel.Topic)
+	if err != nil {
+		return err
+	}
+	outbox.NewRelay(database.NewGormOutboxRepository(u.db), eventBus, outbox.DefaultRelayConfig()).Start(ctx)
+
+	anonymizerConfig := retention.DefaultAnonymizerConfig()
+	if value := os.Getenv("USER_DELETED_RETENTION"); value != "" {
+		anonymizerConfig.Retention, err = time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+	}
+	retention.NewAnonymizer(database.NewGormUserRepository(u.db), anonymizerConfig).Start(ctx)
+
+	consumersConfig, err := messaging.ConsumersConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	u.consumerGroup = consumersConfig.Group
+
+	u.consumers, err = messaging.RegisterConsumers(consumersConfig, map[string]handler.Action{
+		"person.updated":   &syncperson.Command{},
+		"user.deactivated": &deactivateuser.Command{},
+	})
+	if err != nil {
+		return err
+	}
+
 	u.registerActions()
 	u.WithHttpProtocol()
-	return nil
+	return u.bootstrapAdmins(ctx)
+}
+
+// bootstrapAdmins adds the USER_BOOTSTRAP_ADMINS usernames to the seeded
+// admins group, otherwise nobody could call a guarded route on a new database.
+func (u *userModule) bootstrapAdmins(ctx context.Context) error {
+	var usernames []string
+	for _, username := range strings.Split(os.Getenv("USER_BOOTSTRAP_ADMINS"), ",") {
+		if username = strings.TrimSpace(username); username != "" {
+			usernames = append(usernames, username)
+		}
+	}
+
+	_, err := bootstrapadmins.NewComandHandler(u.repository, u.groups, u.guard).
+		Handle(ctx, &bootstrapadmins.Command{Usernames: usernames})
+	return err
+}
+
+func (u *userModule) StartConsumers(ctx context.Context) error {
+	return u.consumers.Start(ctx)
+}
+
+func (u *userModule) StopConsumers() {
+	u.consumers.Stop()
 }
 
 func (u *userModule) WithHttpProtocol() *userModule {
 	router := u.httpLib.Group("/users")
 	http.CreateUserHandler(router)
+	http.ListUsersHandler(router, u.guard)
+	http.GetUserHandler(router, u.guard)
+	http.UpdateUserHandler(router, u.db, u.guard)
+	http.VerifyEmailHandler(router)
+	http.ResendVerificationHandler(router)
+	http.DeactivateUserHandler(router, u.guard)
+	http.ReactivateUserHandler(router, u.guard)
+	http.DeleteUserHandler(router, u.guard)
 	slog.Info("User module started with http", "prefix", "/users")
+
+	authRouter := u.httpLib.Group("/auth")
+	http.LoginHandler(authRouter)
+	http.RefreshTokenHandler(authRouter)
+	http.LogoutHandler(authRouter)
+	http.RequestPasswordResetHandler(authRouter)
+	http.ResetPasswordHandler(authRouter, u.db)
+	http.ChangePasswordHandler(authRouter, u.db, u.guard)
+	http.ListDevicesHandler(authRouter, u.guard)
+	http.RevokeDeviceHandler(authRouter, u.guard)
+	http.RevokeOtherDevicesHandler(authRouter, u.guard)
+	slog.Info("User module started with http", "prefix", "/auth")
+
+	groupsRouter := u.httpLib.Group("/groups")
+	http.CreateGroupHandler(groupsRouter, u.guard)
+	http.RenameGroupHandler(groupsRouter, u.guard)
+	http.DeleteGroupHandler(groupsRouter, u.guard)
+	http.GrantGroupPermissionHandler(groupsRouter, u.guard)
+	http.RevokeGroupPermissionHandler(groupsRouter, u.guard)
+	http.AddGroupUserHandler(groupsRouter, u.guard)
+	http.RemoveGroupUserHandler(groupsRouter, u.guard)
+	http.SetUserMainGroupHandler(groupsRouter, u.guard)
+	slog.Info("User module started with http", "prefix", "/groups")
 	return u
 }
 
 func (u *userModule) registerActions() {
-	gomes.AddActionHandler(createuser.NewComandHandler(u.repository))
+	addActionHandler(createuser.NewComandHandler(u.repository, u.passwordHasher, u.breachedChecker, u.agePolicy, u.verification, u.notifier))
+	addActionHandler(getuser.NewQueryHandler(u.repository, u.dataSource))
+	addActionHandler(listusers.NewQueryHandler(database.NewGormUserReader(u.db)))
+	addActionHandler(updateuserprofile.NewComandHandler(u.repository))
+	addActionHandler(changeusername.NewComandHandler(u.repository))
+	addActionHandler(verifyemail.NewComandHandler(u.repository, u.verification))
+	addActionHandler(resendverification.NewComandHandler(u.repository, u.verification, u.notifier))
+	addActionHandler(messaging.Idempotent[*deactivateuser.Command, any](u.consumerGroup, u.processed,
+		messaging.AcknowledgeApplied[*deactivateuser.Command, any](
+			deactivateuser.NewComandHandler(u.repository, u.refreshTokens, u.guard),
+			deactivateuser.AlreadyDeactivated,
+		),
+	))
+	addActionHandler(reactivateuser.NewComandHandler(u.repository, u.guard))
+	addActionHandler(deleteuser.NewComandHandler(u.repository, u.refreshTokens, u.guard))
+	addActionHandler(auth.NewLoginHandler(u.repository, u.refreshTokens, u.passwordHasher, u.accessTokens, u.refreshTokenTTL))
+	addActionHandler(auth.NewRefreshHandler(u.repository, u.refreshTokens, u.accessTokens, u.refreshTokenTTL))
+	addActionHandler(auth.NewLogoutHandler(u.refreshTokens))
+	addActionHandler(listdevices.NewQueryHandler(database.NewGormDeviceReader(u.db)))
+	addActionHandler(revokedevice.NewComandHandler(u.repository, u.refreshTokens))
+	addActionHandler(revokeotherdevices.NewComandHandler(u.repository, u.refreshTokens))
+	passwords := password.NewService(u.passwordHasher, u.breachedChecker)
+	addActionHandler(requestpasswordreset.NewComandHandler(u.repository, u.passwordReset, u.notifier))
+	addActionHandler(resetpassword.NewComandHandler(u.repository, passwords, u.refreshTokens, u.guard))
+	addActionHandler(changepassword.NewComandHandler(u.repository, passwords, u.refreshTokens, u.guard))
+	addActionHandler(creategroup.NewComandHandler(u.groups))
+	addActionHandler(renamegroup.NewComandHandler(u.groups, u.guard))
+	addActionHandler(deletegroup.NewComandHandler(u.groups, u.guard))
+	addActionHandler(grantgrouppermission.NewComandHandler(u.groups, u.guard))
+	addActionHandler(revokegrouppermission.NewComandHandler(u.groups, u.guard))
+	addActionHandler(addgroupuser.NewComandHandler(u.groups, u.guard))
+	addActionHandler(removegroupuser.NewComandHandler(u.groups, u.guard))
+	addActionHandler(setusermaingroup.NewComandHandler(u.groups))
+	addActionHandler(messaging.Idempotent[*syncperson.Command, any](u.consumerGroup, u.processed, syncperson.NewComandHandler(u.persons)))
+}
+
+// addActionHandler registers the handler measuring its latency and errors.
+func addActionHandler[T handler.Action, U any](actionHandler handler.ActionHandler[T, U]) {
+	gomes.AddActionHandler(observability.Measure(actionHandler))
+}
+
+func verificationPolicyFromEnv() (*domain.VerificationPolicy, error) {
+	policy := domain.DefaultVerificationPolicy()
+	if value := os.Getenv("USER_VERIFICATION_CODE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		policy.TTL = ttl
+	}
+
+	if value := os.Getenv("USER_VERIFICATION_RESEND_COOLDOWN"); value != "" {
+		cooldown, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		policy.ResendCooldown = cooldown
+	}
+
+	if value := os.Getenv("USER_VERIFICATION_MAX_ATTEMPTS"); value != "" {
+		attempts, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		policy.MaxAttempts = attempts
+	}
+
+	if value := os.Getenv("USER_VERIFICATION_ATTEMPTS_WINDOW"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		policy.AttemptsWindow = window
+	}
+
+	return policy, nil
 }
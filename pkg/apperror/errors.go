@@ -0,0 +1,34 @@
+package apperror
+
+type (
+	abstractError struct {
+		message string
+	}
+	UnauthorizedError struct {
+		abstractError
+	}
+	ForbiddenError struct {
+		abstractError
+	}
+	// ConflictError reports a write based on stale data, ex: an outdated
+	// optimistic concurrency version.
+	ConflictError struct {
+		abstractError
+	}
+)
+
+func NewUnauthorizedError(message string) *UnauthorizedError {
+	return &UnauthorizedError{abstractError{message: message}}
+}
+
+func NewForbiddenError(message string) *ForbiddenError {
+	return &ForbiddenError{abstractError{message: message}}
+}
+
+func NewConflictError(message string) *ConflictError {
+	return &ConflictError{abstractError{message: message}}
+}
+
+func (e *abstractError) Error() string {
+	return e.message
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+type Algorithm interface {
+	Name() string
+	Sign(signingInput []byte) ([]byte, error)
+	Verify(signingInput []byte, signature []byte) bool
+}
+
+type hs256 struct {
+	secret []byte
+}
+
+// NewHS256 requires at least 256 bits of secret, as recommended by RFC 7518.
+func NewHS256(secret []byte) (Algorithm, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("HS256 secret must have at least 32 bytes, got %d", len(secret))
+	}
+	return &hs256{secret: secret}, nil
+}
+
+func (a *hs256) Name() string {
+	return "HS256"
+}
+
+func (a *hs256) Sign(signingInput []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write(signingInput)
+	return mac.Sum(nil), nil
+}
+
+func (a *hs256) Verify(signingInput []byte, signature []byte) bool {
+	expected, _ := a.Sign(signingInput)
+	return hmac.Equal(expected, signature)
+}
+
+type edDSA struct {
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+func NewEdDSA(seed []byte) (Algorithm, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("EdDSA seed must have %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	return &edDSA{
+		privateKey: privateKey,
+		publicKey:  privateKey.Public().(ed25519.PublicKey),
+	}, nil
+}
+
+func (a *edDSA) Name() string {
+	return "EdDSA"
+}
+
+func (a *edDSA) Sign(signingInput []byte) ([]byte, error) {
+	return ed25519.Sign(a.privateKey, signingInput), nil
+}
+
+func (a *edDSA) Verify(signingInput []byte, signature []byte) bool {
+	return ed25519.Verify(a.publicKey, signingInput, signature)
+}
@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+)
+
+type Claims struct {
+	Id        string `json:"jti"`
+	Issuer    string `json:"iss,omitempty"`
+	Subject   string `json:"sub"`
+	DeviceId  string `json:"did,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+type JWT struct {
+	algorithm Algorithm
+	issuer    string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+func NewJWT(algorithm Algorithm, issuer string, ttl time.Duration) *JWT {
+	return &JWT{
+		algorithm: algorithm,
+		issuer:    issuer,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// NewJWTFromEnv reads AUTH_JWT_ALGORITHM (HS256 or EdDSA), the matching key
+// (AUTH_JWT_SECRET or the base64 AUTH_JWT_ED25519_SEED), AUTH_JWT_ISSUER and
+// AUTH_ACCESS_TOKEN_TTL.
+func NewJWTFromEnv() (*JWT, error) {
+	var algorithm Algorithm
+	var err error
+
+	switch os.Getenv("AUTH_JWT_ALGORITHM") {
+	case "", "HS256":
+		algorithm, err = NewHS256([]byte(os.Getenv("AUTH_JWT_SECRET")))
+	case "EdDSA":
+		seed, errDecode := base64.StdEncoding.DecodeString(os.Getenv("AUTH_JWT_ED25519_SEED"))
+		if errDecode != nil {
+			return nil, fmt.Errorf("decode AUTH_JWT_ED25519_SEED: %w", errDecode)
+		}
+		algorithm, err = NewEdDSA(seed)
+	default:
+		return nil, fmt.Errorf("unsupported AUTH_JWT_ALGORITHM %q", os.Getenv("AUTH_JWT_ALGORITHM"))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := 15 * time.Minute
+	if value := os.Getenv("AUTH_ACCESS_TOKEN_TTL"); value != "" {
+		ttl, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("parse AUTH_ACCESS_TOKEN_TTL: %w", err)
+		}
+	}
+
+	return NewJWT(algorithm, os.Getenv("AUTH_JWT_ISSUER"), ttl), nil
+}
+
+func (j *JWT) Issue(subject string, deviceId string) (string, time.Time, error) {
+	now := j.now()
+	expiresAt := now.Add(j.ttl)
+	claims := &Claims{
+		Id:        uuid.NewString(),
+		Issuer:    j.issuer,
+		Subject:   subject,
+		DeviceId:  deviceId,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	headerSegment, err := encodeSegment(&header{Algorithm: j.algorithm.Name(), Type: "JWT"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	claimsSegment, err := encodeSegment(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	signingInput := headerSegment + "." + claimsSegment
+	signature, err := j.algorithm.Sign([]byte(signingInput))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), expiresAt, nil
+}
+
+// Parse only accepts tokens signed with the configured algorithm, so a token
+// declaring "none" or a different algorithm is always rejected.
+func (j *JWT) Parse(token string) (*Claims, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, apperror.NewUnauthorizedError("malformed token")
+	}
+
+	var tokenHeader header
+	if err := decodeSegment(segments[0], &tokenHeader); err != nil {
+		return nil, apperror.NewUnauthorizedError("malformed token header")
+	}
+
+	if tokenHeader.Algorithm != j.algorithm.Name() {
+		return nil, apperror.NewUnauthorizedError("unexpected token algorithm")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("malformed token signature")
+	}
+
+	if !j.algorithm.Verify([]byte(segments[0]+"."+segments[1]), signature) {
+		return nil, apperror.NewUnauthorizedError("invalid token signature")
+	}
+
+	var claims Claims
+	if err := decodeSegment(segments[1], &claims); err != nil {
+		return nil, apperror.NewUnauthorizedError("malformed token claims")
+	}
+
+	if j.now().Unix() >= claims.ExpiresAt {
+		return nil, apperror.NewUnauthorizedError("token expired")
+	}
+
+	if j.issuer != "" && claims.Issuer != j.issuer {
+		return nil, apperror.NewUnauthorizedError("unexpected token issuer")
+	}
+
+	return &claims, nil
+}
+
+func encodeSegment(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
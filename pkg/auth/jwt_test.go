@@ -0,0 +1,95 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/auth"
+)
+
+func hs256(t *testing.T, secret string) auth.Algorithm {
+	algorithm, err := auth.NewHS256([]byte(secret))
+	if err != nil {
+		t.Fatalf("Should create HS256 algorithm, got: %v", err)
+	}
+	return algorithm
+}
+
+func TestJWTIssueAndParse(t *testing.T) {
+	edDSA, _ := auth.NewEdDSA(make([]byte, ed25519.SeedSize))
+	algorithms := []auth.Algorithm{hs256(t, strings.Repeat("k", 32)), edDSA}
+
+	for _, algorithm := range algorithms {
+		t.Run("Should parse a token issued with "+algorithm.Name(), func(t *testing.T) {
+			t.Parallel()
+			jwt := auth.NewJWT(algorithm, "hex-api-go", time.Minute)
+			token, expiresAt, err := jwt.Issue("user-uuid-1", "device-1")
+			if err != nil {
+				t.Fatalf("Should issue a token, got: %v", err)
+			}
+
+			claims, err := jwt.Parse(token)
+			if err != nil {
+				t.Fatalf("Should parse the token, got: %v", err)
+			}
+
+			if claims.Subject != "user-uuid-1" || claims.DeviceId != "device-1" {
+				t.Errorf("Should keep subject and device, got: %+v", claims)
+			}
+
+			if claims.ExpiresAt != expiresAt.Unix() {
+				t.Errorf("Should expire at %d, got: %d", expiresAt.Unix(), claims.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestJWTParseRejections(t *testing.T) {
+	jwt := auth.NewJWT(hs256(t, strings.Repeat("k", 32)), "hex-api-go", time.Minute)
+	token, _, _ := jwt.Issue("user-uuid-1", "")
+	segments := strings.Split(token, ".")
+
+	otherKey := auth.NewJWT(hs256(t, strings.Repeat("x", 32)), "hex-api-go", time.Minute)
+	otherIssuer := auth.NewJWT(hs256(t, strings.Repeat("k", 32)), "other", time.Minute)
+	expired := auth.NewJWT(hs256(t, strings.Repeat("k", 32)), "hex-api-go", -time.Minute)
+	expiredToken, _, _ := expired.Issue("user-uuid-1", "")
+
+	var cases = []struct {
+		description string
+		parser      *auth.JWT
+		token       string
+	}{
+		{"Should reject a malformed token", jwt, "not-a-token"},
+		{"Should reject a token signed with another key", otherKey, token},
+		{"Should reject a token from another issuer", otherIssuer, token},
+		{"Should reject an expired token", jwt, expiredToken},
+		{"Should reject the none algorithm", jwt, "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + segments[1] + "."},
+		{"Should reject a tampered payload", jwt, segments[0] + "." + segments[1] + "e30." + segments[2]},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			claims, err := c.parser.Parse(c.token)
+			if claims != nil {
+				t.Errorf("Should return nil claims, got: %+v", claims)
+			}
+
+			if _, ok := err.(*apperror.UnauthorizedError); !ok {
+				t.Errorf("Should return an UnauthorizedError, got: %T %v", err, err)
+			}
+		})
+	}
+}
+
+func TestNewHS256(t *testing.T) {
+	t.Run("Should reject a short secret", func(t *testing.T) {
+		t.Parallel()
+		if _, err := auth.NewHS256([]byte("short")); err == nil {
+			t.Error("Should return an error, got nil")
+		}
+	})
+}
@@ -1,11 +0,0 @@
-package pkg
-
-import "gorm.io/gorm"
-
-type GormRepository struct {
-	Db *gorm.DB
-}
-
-func (*GormRepository) AutoMigrateModels(models ...*gorm.Model) {
-	
-}
\ No newline at end of file
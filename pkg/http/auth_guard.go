@@ -0,0 +1,208 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/auth"
+)
+
+const (
+	principalKey   = "auth.principal"
+	maxCachedUsers = 10000
+)
+
+// Permission is the pair stored in UserGroupsPermissions: the api route
+// application a route belongs to and the action performed on it.
+type Permission struct {
+	Resource string
+	Action   string
+}
+
+func (p Permission) String() string {
+	return p.Resource + ":" + p.Action
+}
+
+type ResolvedPermissions struct {
+	Groups      []string
+	Permissions []Permission
+}
+
+type TokenParser interface {
+	Parse(token string) (*auth.Claims, error)
+}
+
+type PermissionResolver interface {
+	Resolve(ctx context.Context, userId string) (*ResolvedPermissions, error)
+}
+
+type Principal struct {
+	UserId      string
+	DeviceId    string
+	Groups      []string
+	permissions map[Permission]struct{}
+}
+
+func (p *Principal) Can(permission Permission) bool {
+	_, ok := p.permissions[permission]
+	return ok
+}
+
+type cachedPermissions struct {
+	resolved  *ResolvedPermissions
+	expiresAt time.Time
+}
+
+type AuthGuard struct {
+	tokens   TokenParser
+	resolver PermissionResolver
+	cacheTTL time.Duration
+	mu       sync.RWMutex
+	cache    map[string]cachedPermissions
+}
+
+func NewAuthGuard(tokens TokenParser, resolver PermissionResolver, cacheTTL time.Duration) *AuthGuard {
+	return &AuthGuard{
+		tokens:   tokens,
+		resolver: resolver,
+		cacheTTL: cacheTTL,
+		cache:    map[string]cachedPermissions{},
+	}
+}
+
+// Authenticate validates the bearer token and stores the caller, with its
+// groups and permissions, in the gin context.
+func (g *AuthGuard) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if g.authenticate(c) {
+			c.Next()
+		}
+	}
+}
+
+// Require authenticates the caller and rejects it with 403 when none of its
+// groups grants the declared permission.
+func (g *AuthGuard) Require(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !g.authenticate(c) {
+			return
+		}
+
+		principal, _ := PrincipalFromContext(c)
+		if !principal.Can(permission) {
+			ErrorWithCode(c, 403, apperror.NewForbiddenError("missing permission "+permission.String()))
+			c.Abort()
+		}
+	}
+}
+
+func (g *AuthGuard) authenticate(c *gin.Context) bool {
+	if _, ok := PrincipalFromContext(c); ok {
+		return true
+	}
+
+	principal, err := g.principal(c)
+	var unauthorized *apperror.UnauthorizedError
+	if errors.As(err, &unauthorized) {
+		c.Header("WWW-Authenticate", `Bearer realm="api"`)
+		ErrorWithCode(c, 401, err)
+		c.Abort()
+		return false
+	}
+
+	if err != nil {
+		Error(c, err)
+		c.Abort()
+		return false
+	}
+
+	c.Set(principalKey, principal)
+	return true
+}
+
+// Invalidate drops the cached permissions of a user, so changes to its groups
+// take effect on the next request instead of after the cache TTL.
+func (g *AuthGuard) Invalidate(userId string) {
+	g.mu.Lock()
+	delete(g.cache, userId)
+	g.mu.Unlock()
+}
+
+func (g *AuthGuard) principal(c *gin.Context) (*Principal, error) {
+	header := c.GetHeader("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, apperror.NewUnauthorizedError("missing bearer token")
+	}
+
+	claims, err := g.tokens.Parse(strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := g.resolve(c.Request.Context(), claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[Permission]struct{}, len(resolved.Permissions))
+	for _, permission := range resolved.Permissions {
+		permissions[permission] = struct{}{}
+	}
+
+	return &Principal{
+		UserId:      claims.Subject,
+		DeviceId:    claims.DeviceId,
+		Groups:      resolved.Groups,
+		permissions: permissions,
+	}, nil
+}
+
+func (g *AuthGuard) resolve(ctx context.Context, userId string) (*ResolvedPermissions, error) {
+	now := time.Now()
+	g.mu.RLock()
+	cached, ok := g.cache[userId]
+	g.mu.RUnlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.resolved, nil
+	}
+
+	resolved, err := g.resolver.Resolve(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	if _, cached := g.cache[userId]; !cached && len(g.cache) >= maxCachedUsers {
+		for key, entry := range g.cache {
+			if !now.Before(entry.expiresAt) {
+				delete(g.cache, key)
+			}
+		}
+		// still full of live entries: drop arbitrary ones, map iteration
+		// order is random and a dropped user is only resolved again
+		for key := range g.cache {
+			if len(g.cache) < maxCachedUsers {
+				break
+			}
+			delete(g.cache, key)
+		}
+	}
+	g.cache[userId] = cachedPermissions{resolved: resolved, expiresAt: now.Add(g.cacheTTL)}
+	g.mu.Unlock()
+	return resolved, nil
+}
+
+func PrincipalFromContext(c *gin.Context) (*Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*Principal)
+	return principal, ok
+}
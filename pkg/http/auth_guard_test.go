@@ -0,0 +1,102 @@
+package http_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/auth"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type fakeResolver struct {
+	calls atomic.Int32
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, userId string) (*http.ResolvedPermissions, error) {
+	r.calls.Add(1)
+	if userId != "admin" {
+		return &http.ResolvedPermissions{Groups: []string{"guests"}}, nil
+	}
+	return &http.ResolvedPermissions{
+		Groups:      []string{"admins"},
+		Permissions: []http.Permission{{Resource: "users", Action: "read"}},
+	}, nil
+}
+
+func setupGuard(t *testing.T) (*gin.Engine, *http.AuthGuard, *fakeResolver, *auth.JWT) {
+	gin.SetMode(gin.TestMode)
+	algorithm, _ := auth.NewHS256([]byte(strings.Repeat("k", 32)))
+	jwt := auth.NewJWT(algorithm, "", time.Minute)
+	resolver := &fakeResolver{}
+	guard := http.NewAuthGuard(jwt, resolver, time.Minute)
+
+	engine := gin.New()
+	engine.GET("/users", guard.Require(http.Permission{Resource: "users", Action: "read"}), func(c *gin.Context) {
+		principal, _ := http.PrincipalFromContext(c)
+		c.String(200, principal.UserId)
+	})
+	return engine, guard, resolver, jwt
+}
+
+func request(engine *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/users", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestAuthGuardRequire(t *testing.T) {
+	engine, _, _, jwt := setupGuard(t)
+	adminToken, _, _ := jwt.Issue("admin", "")
+	guestToken, _, _ := jwt.Issue("guest", "")
+
+	var cases = []struct {
+		description   string
+		authorization string
+		expected      int
+	}{
+		{"Should return 401 without a bearer token", "", 401},
+		{"Should return 401 with another scheme", "Basic " + adminToken, 401},
+		{"Should return 401 with an invalid token", "Bearer invalid.token.value", 401},
+		{"Should return 403 when no group grants the permission", "Bearer " + guestToken, 403},
+		{"Should call the route when a group grants the permission", "Bearer " + adminToken, 200},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			recorder := request(engine, c.authorization)
+			if recorder.Code != c.expected {
+				t.Errorf("Should return %d, got: %d %s", c.expected, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthGuardCache(t *testing.T) {
+	t.Run("Should resolve permissions once per user until invalidated", func(t *testing.T) {
+		t.Parallel()
+		engine, guard, resolver, jwt := setupGuard(t)
+		token, _, _ := jwt.Issue("admin", "")
+
+		request(engine, "Bearer "+token)
+		request(engine, "Bearer "+token)
+		if resolver.calls.Load() != 1 {
+			t.Errorf("Should resolve once, got: %d", resolver.calls.Load())
+		}
+
+		guard.Invalidate("admin")
+		request(engine, "Bearer "+token)
+		if resolver.calls.Load() != 2 {
+			t.Errorf("Should resolve again after Invalidate, got: %d", resolver.calls.Load())
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Optional is a field of a JSON Merge Patch (RFC 7396) request: it tells a
+// member left out of the document (keep) from one set to null (remove).
+type Optional[T any] struct {
+	Present bool
+	Null    bool
+	Value   T
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Present = true
+	if string(data) == "null" {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// Pointer returns nil when the member is absent and the zero value when it
+// is null, the shape commands use for optional changes.
+func (o Optional[T]) Pointer() *T {
+	if !o.Present {
+		return nil
+	}
+	value := o.Value
+	return &value
+}
+
+// ETag renders a resource version as a strong entity tag, ex: "3".
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// IfMatchVersion reads the version sent back by the client in If-Match.
+func IfMatchVersion(header string) (int, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(header), "W/")
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("If-Match must be the ETag of the resource, got %q", header)
+	}
+	return version, nil
+}
@@ -0,0 +1,86 @@
+package http_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+)
+
+type patchRequest struct {
+	Name     http.Optional[string]   `json:"name"`
+	Contacts http.Optional[[]string] `json:"contacts"`
+}
+
+func TestOptional(t *testing.T) {
+	cases := []struct {
+		description string
+		body        string
+		present     bool
+		null        bool
+		expected    *string
+	}{
+		{"Should keep an absent member", `{}`, false, false, nil},
+		{"Should flag a null member", `{"name":null}`, true, true, new(string)},
+		{"Should read a set member", `{"name":"John"}`, true, false, func() *string { v := "John"; return &v }()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			var request patchRequest
+			if err := json.Unmarshal([]byte(c.body), &request); err != nil {
+				t.Fatalf("Should unmarshal, got: %v", err)
+			}
+
+			if request.Name.Present != c.present || request.Name.Null != c.null {
+				t.Errorf("Should return present=%v null=%v, got: %+v", c.present, c.null, request.Name)
+			}
+
+			pointer := request.Name.Pointer()
+			if (pointer == nil) != (c.expected == nil) || (pointer != nil && *pointer != *c.expected) {
+				t.Errorf("Should return %v, got: %v", c.expected, pointer)
+			}
+		})
+	}
+
+	t.Run("Should fail when the member has the wrong type", func(t *testing.T) {
+		t.Parallel()
+		var request patchRequest
+		if err := json.Unmarshal([]byte(`{"contacts":"x"}`), &request); err == nil {
+			t.Error("Should return an error")
+		}
+	})
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	cases := []struct {
+		description string
+		header      string
+		expected    int
+		fails       bool
+	}{
+		{"Should read a strong ETag", `"3"`, 3, false},
+		{"Should read a weak ETag", `W/"7"`, 7, false},
+		{"Should fail without a header", ``, 0, true},
+		{"Should fail with a wildcard", `*`, 0, true},
+		{"Should fail with a version below one", `"0"`, 0, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			version, err := http.IfMatchVersion(c.header)
+			if (err != nil) != c.fails || version != c.expected {
+				t.Errorf("Should return %d (fails=%v), got: %d, %v", c.expected, c.fails, version, err)
+			}
+		})
+	}
+
+	t.Run("Should render the version as an ETag", func(t *testing.T) {
+		t.Parallel()
+		if http.ETag(3) != `"3"` {
+			t.Errorf("Should return \"3\", got: %s", http.ETag(3))
+		}
+	})
+}
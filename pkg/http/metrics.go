@@ -0,0 +1,55 @@
+package http
+
+import (
+	httpLib "net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+// durationBuckets are the boundaries, in seconds, recommended by the HTTP
+// semantic conventions.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// Metrics records the request duration histogram by route template and
+// status code. Register it after Tracing so the measurements carry the
+// request trace as exemplar.
+func Metrics() (gin.HandlerFunc, error) {
+	duration, err := otel.Meter(instrumentationName).Float64Histogram(
+		"http.server.request.duration",
+		metric.WithDescription("Duration of HTTP server requests."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attributes := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.HTTPResponseStatusCode(status),
+		}
+		if route := c.FullPath(); route != "" {
+			attributes = append(attributes, semconv.HTTPRoute(route))
+		}
+		if status >= httpLib.StatusInternalServerError {
+			attributes = append(attributes, semconv.ErrorTypeKey.String(strconv.Itoa(status)))
+		}
+
+		duration.Record(
+			c.Request.Context(),
+			time.Since(start).Seconds(),
+			metric.WithAttributes(attributes...),
+		)
+	}, nil
+}
@@ -0,0 +1,64 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(reader)))
+
+	middleware, err := http.Metrics()
+	if err != nil {
+		t.Fatalf("Should create the middleware, got: %v", err)
+	}
+
+	engine := gin.New()
+	engine.Use(middleware)
+	engine.GET("/users/:id", func(c *gin.Context) {
+		if c.Param("id") == "broken" {
+			http.Error(c, errors.New("database unavailable"))
+			return
+		}
+		c.Status(200)
+	})
+
+	for _, path := range []string{"/users/1", "/users/2", "/users/broken"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Should collect the metrics, got: %v", err)
+	}
+
+	counts := map[int64]uint64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "http.server.request.duration" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				if route, _ := point.Attributes.Value("http.route"); route.AsString() != "/users/:id" {
+					t.Errorf("Should label the route template, got: %s", route.AsString())
+				}
+				status, _ := point.Attributes.Value("http.response.status_code")
+				counts[status.AsInt64()] += point.Count
+			}
+		}
+	}
+
+	if counts[200] != 2 || counts[500] != 1 {
+		t.Errorf("Should record 2 requests with 200 and 1 with 500, got: %v", counts)
+	}
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	httpLib "net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/log"
+)
+
+const (
+	requestIdHeader    = "X-Request-Id"
+	maxRequestIdLength = 128
+)
+
+// RequestLogger replaces the gin logger: it keeps the caller X-Request-Id or
+// creates one, stores it in the request context for every log of the request
+// and writes one access log through slog. Register it after Tracing so the
+// access log carries the trace id. The query string is left out of the log,
+// it may carry personal data.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestId := c.GetHeader(requestIdHeader)
+		if requestId == "" || len(requestId) > maxRequestIdLength {
+			requestId = uuid.NewString()
+		}
+
+		c.Header(requestIdHeader, requestId)
+		c.Request = c.Request.WithContext(log.WithRequestId(c.Request.Context(), requestId))
+		c.Next()
+
+		status := c.Writer.Status()
+		attributes := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("clientIp", c.ClientIP()),
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= httpLib.StatusInternalServerError:
+			level = slog.LevelError
+			if last := c.Errors.Last(); last != nil {
+				attributes = append(attributes, slog.String("error", last.Error()))
+			}
+		case status >= httpLib.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		slog.LogAttrs(c.Request.Context(), level, "http request", attributes...)
+	}
+}
+
+// Recovery replaces the gin recovery, logging the panic and its stack
+// through slog instead of gin's own writer.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		slog.ErrorContext(c.Request.Context(), "panic recovered",
+			"error", fmt.Sprint(err),
+			"stack", string(debug.Stack()),
+		)
+		c.AbortWithStatus(httpLib.StatusInternalServerError)
+	})
+}
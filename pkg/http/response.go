@@ -14,6 +14,7 @@ import (
 	en_translations "github.com/go-playground/validator/v10/translations/en"
 	pt_br_translations "github.com/go-playground/validator/v10/translations/pt_BR"
 	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/apperror"
 )
 
 var GlobalTranslator *ut.UniversalTranslator
@@ -51,12 +52,20 @@ func Error(c *gin.Context, err error) {
 		ErrorWithCode(c, 502, err)
 	case *ddgo.InvalidDataError:
 		ErrorWithCode(c, 422, err)
+	case *apperror.UnauthorizedError:
+		ErrorWithCode(c, 401, err)
+	case *apperror.ForbiddenError:
+		ErrorWithCode(c, 403, err)
+	case *apperror.ConflictError:
+		ErrorWithCode(c, 409, err)
 	default:
 		ErrorWithCode(c, 500, err)
 	}
 }
 
 func ErrorWithCode(c *gin.Context, code int, err error) {
+	// keeps the error on the context so the tracing middleware records it
+	c.Error(err)
 
 	var ve validator.ValidationErrors
 	if !errors.As(err, &ve) {
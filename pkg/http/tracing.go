@@ -0,0 +1,94 @@
+package http
+
+import (
+	httpLib "net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter of this package.
+const instrumentationName = "github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+
+// Tracing starts a server span for every request, continuing the trace sent
+// by the caller in the traceparent header. The span is stored in the request
+// context, so handlers must dispatch with c.Request.Context() for the bus
+// spans to join the same trace.
+func Tracing() gin.HandlerFunc {
+	tracer := otel.Tracer(instrumentationName)
+
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(
+			c.Request.Context(),
+			propagation.HeaderCarrier(c.Request.Header),
+		)
+
+		route := c.FullPath()
+		attributes := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.URLPath(c.Request.URL.Path),
+			semconv.URLScheme(scheme(c)),
+			semconv.ClientAddress(c.ClientIP()),
+			semconv.UserAgentOriginal(c.Request.UserAgent()),
+		}
+		if route != "" {
+			attributes = append(attributes, semconv.HTTPRoute(route))
+		}
+		if c.Request.ContentLength > 0 {
+			attributes = append(attributes, semconv.HTTPRequestBodySize(int(c.Request.ContentLength)))
+		}
+
+		ctx, span := tracer.Start(
+			ctx,
+			spanName(c.Request.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attributes...),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(
+			semconv.HTTPResponseStatusCode(status),
+			semconv.HTTPResponseBodySize(max(c.Writer.Size(), 0)),
+		)
+
+		for _, err := range c.Errors {
+			span.RecordError(err.Err)
+		}
+
+		// client errors are the caller's fault, only server errors fail the span
+		if status >= httpLib.StatusInternalServerError {
+			span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(status)))
+			description := httpLib.StatusText(status)
+			if last := c.Errors.Last(); last != nil {
+				description = last.Error()
+			}
+			span.SetStatus(codes.Error, description)
+		}
+	}
+}
+
+func spanName(method string, route string) string {
+	if route == "" {
+		return method
+	}
+
+	return method + " " + route
+}
+
+func scheme(c *gin.Context) string {
+	if c.Request.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
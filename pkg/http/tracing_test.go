@@ -0,0 +1,137 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (r *spanRecorder) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func (r *spanRecorder) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (r *spanRecorder) last() sdktrace.ReadOnlySpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.spans) == 0 {
+		return nil
+	}
+	return r.spans[len(r.spans)-1]
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func setupTracing(t *testing.T) (*gin.Engine, *spanRecorder, *trace.SpanContext) {
+	gin.SetMode(gin.TestMode)
+	recorder := &spanRecorder{}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	handlerSpan := &trace.SpanContext{}
+	engine := gin.New()
+	engine.Use(http.Tracing())
+	engine.POST("/users/:id", func(c *gin.Context) {
+		*handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		switch c.Param("id") {
+		case "missing":
+			http.Error(c, ddgo.NewNotFoundError("user not found"))
+		case "broken":
+			http.Error(c, errors.New("database unavailable"))
+		default:
+			c.Status(204)
+		}
+	})
+	return engine, recorder, handlerSpan
+}
+
+func TestTracing(t *testing.T) {
+	engine, recorder, handlerSpan := setupTracing(t)
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	var cases = []struct {
+		description string
+		path        string
+		status      int
+		spanStatus  codes.Code
+		recorded    bool
+	}{
+		{"Should close an ok span for a successful request", "/users/1", 204, codes.Unset, false},
+		{"Should record the error without failing the span on client errors", "/users/missing", 404, codes.Unset, true},
+		{"Should fail the span on server errors", "/users/broken", 500, codes.Error, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", c.path, strings.NewReader(`{"name":"john"}`))
+			req.Header.Set("traceparent", traceparent)
+			engine.ServeHTTP(response, req)
+
+			span := recorder.last()
+			if span == nil {
+				t.Fatal("Should export a span")
+			}
+			if span.Name() != "POST /users/:id" {
+				t.Errorf("Should name the span by route template, got: %s", span.Name())
+			}
+			if span.SpanKind() != trace.SpanKindServer {
+				t.Errorf("Should start a server span, got: %s", span.SpanKind())
+			}
+			if span.Parent().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || !span.Parent().IsRemote() {
+				t.Errorf("Should continue the incoming trace, got parent: %v", span.Parent())
+			}
+			if !handlerSpan.Equal(span.SpanContext()) {
+				t.Error("Should inject the span into the request context")
+			}
+			if value, _ := attributeValue(span, "http.response.status_code"); value.AsInt64() != int64(c.status) {
+				t.Errorf("Should record status %d, got: %d", c.status, value.AsInt64())
+			}
+			if value, _ := attributeValue(span, "http.request.body.size"); value.AsInt64() != 15 {
+				t.Errorf("Should record the request size, got: %d", value.AsInt64())
+			}
+			if _, ok := attributeValue(span, "client.address"); !ok {
+				t.Error("Should record the client address")
+			}
+			if span.Status().Code != c.spanStatus {
+				t.Errorf("Should set span status %s, got: %s", c.spanStatus, span.Status().Code)
+			}
+			if recorded := len(span.Events()) > 0 && span.Events()[0].Name == "exception"; recorded != c.recorded {
+				t.Errorf("Should record error %v, got: %v", c.recorded, recorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package log
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// Config drives the logging pipeline shared by the application, gin and
+// gorm.
+type Config struct {
+	// JSON selects the JSON output, text is easier to read locally.
+	JSON  bool
+	Level slog.Level
+	// Queries logs every SQL statement, otherwise only the slow and failed
+	// ones.
+	Queries bool
+}
+
+// ConfigFromEnv logs text and every SQL statement when APP_ENV is local and
+// JSON otherwise. LOG_LEVEL accepts debug, info, warn or error.
+func ConfigFromEnv() (*Config, error) {
+	local := os.Getenv("APP_ENV") == "local"
+	config := &Config{
+		JSON:    !local,
+		Level:   slog.LevelInfo,
+		Queries: local,
+	}
+
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		if err := config.Level.UnmarshalText([]byte(value)); err != nil {
+			return nil, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error, got %q", value)
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,16 @@
+package log
+
+import "context"
+
+type requestIdKey struct{}
+
+// WithRequestId stores the id of the request being served, the handler adds
+// it to every record logged with the returned context.
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
@@ -0,0 +1,28 @@
+package log
+
+import (
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold is the duration from which a statement is logged as a
+// warning even when Queries is off.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// NewGormLogger sends the gorm logs to logger. Statements are logged without
+// their bound values so no personal data reaches the logs through SQL.
+func NewGormLogger(l *slog.Logger, config *Config) logger.Interface {
+	level := logger.Warn
+	if config.Queries {
+		level = logger.Info
+	}
+
+	return logger.NewSlogLogger(l, logger.Config{
+		SlowThreshold:             slowQueryThreshold,
+		IgnoreRecordNotFoundError: true,
+		ParameterizedQueries:      true,
+		LogLevel:                  level,
+	})
+}
@@ -0,0 +1,93 @@
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redacted replaces the value of the sensitive attributes.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are compared lower cased, whatever group the attribute is in.
+var sensitiveKeys = map[string]bool{
+	"password":        true,
+	"currentpassword": true,
+	"newpassword":     true,
+	"passwordhash":    true,
+	"document":        true,
+	"email":           true,
+	"authorization":   true,
+	"secret":          true,
+	"token":           true,
+	"refreshtoken":    true,
+	"code":            true,
+	"to":              true,
+	"contact":         true,
+}
+
+// contextHandler adds the trace and request ids found in the context to
+// every record.
+type contextHandler struct {
+	next slog.Handler
+}
+
+// NewHandler builds the JSON or text handler chosen by config, redacting the
+// sensitive attributes and correlating records with the current trace and
+// request.
+func NewHandler(w io.Writer, config *Config) slog.Handler {
+	options := &slog.HandlerOptions{
+		AddSource:   config.JSON,
+		Level:       config.Level,
+		ReplaceAttr: redact,
+	}
+
+	if config.JSON {
+		return &contextHandler{next: slog.NewJSONHandler(w, options)}
+	}
+
+	return &contextHandler{next: slog.NewTextHandler(w, options)}
+}
+
+// New is a logger over NewHandler.
+func New(w io.Writer, config *Config) *slog.Logger {
+	return slog.New(NewHandler(w, config))
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	if requestId := RequestIdFromContext(ctx); requestId != "" {
+		record.AddAttrs(slog.String("request_id", requestId))
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{next: h.next.WithGroup(name)}
+}
+
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	return attr
+}
@@ -0,0 +1,130 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func logJSON(t *testing.T, ctx context.Context, args ...any) map[string]any {
+	var buffer bytes.Buffer
+	logger := log.New(&buffer, &log.Config{JSON: true, Level: slog.LevelInfo})
+	logger.InfoContext(ctx, "message", args...)
+
+	var record map[string]any
+	if err := json.Unmarshal(buffer.Bytes(), &record); err != nil {
+		t.Fatalf("Should write JSON, got: %s", buffer.String())
+	}
+	return record
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("Should redact sensitive fields whatever their case or group", func(t *testing.T) {
+		t.Parallel()
+		record := logJSON(t, context.Background(),
+			"password", "secret123",
+			"Email", "john@mail.com",
+			slog.Group("user", "document", "12345678900", "userId", "42"),
+		)
+
+		user := record["user"].(map[string]any)
+		if record["password"] != log.Redacted || record["Email"] != log.Redacted || user["document"] != log.Redacted {
+			t.Errorf("Should redact password, email and document, got: %v", record)
+		}
+		if user["userId"] != "42" {
+			t.Errorf("Should keep other fields, got: %v", user)
+		}
+	})
+
+	t.Run("Should redact tokens, codes and their recipient", func(t *testing.T) {
+		t.Parallel()
+		record := logJSON(t, context.Background(),
+			"token", "reset-token",
+			"refreshToken", "refresh-token",
+			"code", "123456",
+			"to", "john@mail.com",
+			"contact", "+5511999999999",
+		)
+
+		for _, key := range []string{"token", "refreshToken", "code", "to", "contact"} {
+			if record[key] != log.Redacted {
+				t.Errorf("Should redact %s, got: %v", key, record[key])
+			}
+		}
+	})
+
+	t.Run("Should add the trace and request ids found in the context", func(t *testing.T) {
+		t.Parallel()
+		traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceId,
+			SpanID:  spanId,
+		}))
+		ctx = log.WithRequestId(ctx, "req-1")
+
+		record := logJSON(t, ctx)
+		if record["trace_id"] != traceId.String() || record["span_id"] != spanId.String() || record["request_id"] != "req-1" {
+			t.Errorf("Should correlate the record, got: %v", record)
+		}
+	})
+
+	t.Run("Should not add ids missing from the context", func(t *testing.T) {
+		t.Parallel()
+		record := logJSON(t, context.Background())
+		for _, key := range []string{"trace_id", "span_id", "request_id"} {
+			if _, ok := record[key]; ok {
+				t.Errorf("Should not add %s, got: %v", key, record)
+			}
+		}
+	})
+
+	t.Run("Should write text when JSON is off", func(t *testing.T) {
+		t.Parallel()
+		var buffer bytes.Buffer
+		log.New(&buffer, &log.Config{Level: slog.LevelInfo}).Info("message", "password", "secret123")
+		if !strings.Contains(buffer.String(), "msg=message password=[REDACTED]") {
+			t.Errorf("Should write a redacted text record, got: %s", buffer.String())
+		}
+	})
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	var cases = []struct {
+		description string
+		env         string
+		level       string
+		expected    log.Config
+	}{
+		{"Should log text and every query locally", "local", "", log.Config{JSON: false, Level: slog.LevelInfo, Queries: true}},
+		{"Should log JSON and slow queries elsewhere", "prod", "warn", log.Config{JSON: true, Level: slog.LevelWarn, Queries: false}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Setenv("APP_ENV", c.env)
+			t.Setenv("LOG_LEVEL", c.level)
+
+			config, err := log.ConfigFromEnv()
+			if err != nil {
+				t.Fatalf("Should read the config, got: %v", err)
+			}
+			if *config != c.expected {
+				t.Errorf("Should be %+v, got: %+v", c.expected, *config)
+			}
+		})
+	}
+
+	t.Run("Should reject an unknown level", func(t *testing.T) {
+		t.Setenv("LOG_LEVEL", "verbose")
+		if _, err := log.ConfigFromEnv(); err == nil {
+			t.Error("Should fail on an unknown level")
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create writes an empty up/down pair in dir, versioned by now, and returns
+// the file paths.
+func Create(dir string, name string, now time.Time) (string, string, error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", fmt.Errorf("[migration] migration name is required")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("[migration] cannot create %s: %w", dir, err)
+	}
+
+	base := fmt.Sprintf("%s_%s", now.UTC().Format("20060102150405"), name)
+	up := filepath.Join(dir, base+".up.sql")
+	down := filepath.Join(dir, base+".down.sql")
+	files := map[string]string{
+		up:   fmt.Sprintf("-- %s: apply\n", base),
+		down: fmt.Sprintf("-- %s: revert the up migration\n", base),
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return "", "", fmt.Errorf("[migration] cannot write %s: %w", path, err)
+		}
+	}
+	return up, down, nil
+}
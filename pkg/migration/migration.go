@@ -0,0 +1,106 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+)
+
+// fileName matches <version>_<name>.<up|down>.sql, ex: 20261016120000_create_users.up.sql
+var fileName = regexp.MustCompile(`^(\d{14})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is one versioned change of a module schema.
+type Migration struct {
+	Module   string
+	Version  string
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Source holds the migration files of a module, usually an embed.FS.
+type Source struct {
+	Module string
+	FS     fs.FS
+}
+
+// Load reads every source and returns the migrations ordered by version, so
+// migrations of different modules apply in the order they were written.
+func Load(sources ...Source) ([]*Migration, error) {
+	migrations := make([]*Migration, 0)
+	for _, source := range sources {
+		loaded, err := loadSource(source)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, loaded...)
+	}
+
+	sort.SliceStable(migrations, func(i, j int) bool {
+		if migrations[i].Version != migrations[j].Version {
+			return migrations[i].Version < migrations[j].Version
+		}
+		return migrations[i].Module < migrations[j].Module
+	})
+	return migrations, nil
+}
+
+func loadSource(source Source) ([]*Migration, error) {
+	entries, err := fs.ReadDir(source.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("[migration] cannot read %s migrations: %w", source.Module, err)
+	}
+
+	byVersion := map[string]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		parts := fileName.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			return nil, fmt.Errorf("[migration] %s: invalid file name %s, expected <yyyymmddhhmmss>_<name>.<up|down>.sql", source.Module, entry.Name())
+		}
+
+		content, err := fs.ReadFile(source.FS, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("[migration] %s: cannot read %s: %w", source.Module, entry.Name(), err)
+		}
+
+		version, name, direction := parts[1], parts[2], parts[3]
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Module: source.Module, Version: version, Name: name}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != name {
+			return nil, fmt.Errorf("[migration] %s: version %s is used by %s and %s", source.Module, version, migration.Name, name)
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("[migration] %s: %s_%s has no up file", source.Module, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+func (m *Migration) String() string {
+	return fmt.Sprintf("%s/%s_%s", m.Module, m.Version, m.Name)
+}
@@ -0,0 +1,114 @@
+package migration_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/migration"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("Should order migrations of every module by version", func(t *testing.T) {
+		t.Parallel()
+		user := fstest.MapFS{
+			"20260102000000_create_users.up.sql":   {Data: []byte("CREATE TABLE users ();")},
+			"20260102000000_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+			"20260101000000_create_schema.up.sql":  {Data: []byte("CREATE SCHEMA app;")},
+		}
+		billing := fstest.MapFS{
+			"20260101120000_create_invoices.up.sql": {Data: []byte("CREATE TABLE invoices ();")},
+		}
+
+		migrations, err := migration.Load(
+			migration.Source{Module: "user", FS: user},
+			migration.Source{Module: "billing", FS: billing},
+		)
+		if err != nil {
+			t.Fatalf("Should load migrations, got: %v", err)
+		}
+
+		expected := []string{"user/20260101000000_create_schema", "billing/20260101120000_create_invoices", "user/20260102000000_create_users"}
+		if len(migrations) != len(expected) {
+			t.Fatalf("Should load %d migrations, got: %d", len(expected), len(migrations))
+		}
+
+		for i, m := range migrations {
+			if m.String() != expected[i] {
+				t.Errorf("Should load %s at %d, got: %s", expected[i], i, m)
+			}
+		}
+
+		if migrations[2].Down != "DROP TABLE users;" || migrations[2].Checksum == "" {
+			t.Errorf("Should read down script and checksum, got: %+v", migrations[2])
+		}
+	})
+
+	cases := []struct {
+		description string
+		files       fstest.MapFS
+		expected    string
+	}{
+		{
+			"Should fail when a file name does not follow the pattern",
+			fstest.MapFS{"create_users.sql": {Data: []byte("")}},
+			"invalid file name create_users.sql",
+		},
+		{
+			"Should fail when a migration has no up file",
+			fstest.MapFS{"20260101000000_create_users.down.sql": {Data: []byte("")}},
+			"20260101000000_create_users has no up file",
+		},
+		{
+			"Should fail when two migrations share a version",
+			fstest.MapFS{
+				"20260101000000_create_users.up.sql":  {Data: []byte("")},
+				"20260101000000_create_groups.up.sql": {Data: []byte("")},
+			},
+			"version 20260101000000 is used by",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			_, err := migration.Load(migration.Source{Module: "user", FS: c.files})
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("Should return error containing %q, got: %v", c.expected, err)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("Should write an up and down file versioned by time", func(t *testing.T) {
+		t.Parallel()
+		dir := filepath.Join(t.TempDir(), "migrations")
+		now := time.Date(2026, 10, 16, 12, 30, 5, 0, time.UTC)
+
+		up, down, err := migration.Create(dir, "Add user Phone", now)
+		if err != nil {
+			t.Fatalf("Should create migration, got: %v", err)
+		}
+
+		if filepath.Base(up) != "20261016123005_add_user_phone.up.sql" || filepath.Base(down) != "20261016123005_add_user_phone.down.sql" {
+			t.Errorf("Should name files by version and snake case name, got: %s, %s", up, down)
+		}
+
+		migrations, err := migration.Load(migration.Source{Module: "user", FS: os.DirFS(dir)})
+		if err != nil || len(migrations) != 1 {
+			t.Errorf("Should create a loadable migration, got: %v, %v", migrations, err)
+		}
+	})
+
+	t.Run("Should fail without a name", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := migration.Create(t.TempDir(), " - ", time.Now())
+		if err == nil {
+			t.Error("Should return an error")
+		}
+	})
+}
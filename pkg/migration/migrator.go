@@ -0,0 +1,207 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Applied is the record a store keeps for every applied migration.
+type Applied struct {
+	Module    string
+	Version   string
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Store persists the applied migrations and serializes migrators, so
+// replicas starting at the same time do not race.
+type Store interface {
+	// Lock blocks until the caller holds the migration lock.
+	Lock(ctx context.Context) error
+	Unlock(ctx context.Context) error
+	// Applied returns the applied migrations, oldest first.
+	Applied(ctx context.Context) ([]Applied, error)
+	// Apply runs the up script and records it atomically.
+	Apply(ctx context.Context, migration *Migration) error
+	// Revert runs the down script and removes its record atomically.
+	Revert(ctx context.Context, migration *Migration) error
+}
+
+// Status describes a migration and whether it was applied.
+type Status struct {
+	Migration *Migration
+	AppliedAt *time.Time
+	// Changed reports an applied migration whose up file changed afterwards.
+	Changed bool
+}
+
+// Migrator only handles the modules of its sources: the records other
+// modules left in the store are ignored.
+type Migrator struct {
+	store      Store
+	migrations []*Migration
+	modules    map[string]bool
+}
+
+func NewMigrator(store Store, sources ...Source) (*Migrator, error) {
+	migrations, err := Load(sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		modules[source.Module] = true
+	}
+
+	return &Migrator{store: store, migrations: migrations, modules: modules}, nil
+}
+
+// Up applies every pending migration in version order and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var executed []*Migration
+	err := m.locked(ctx, func() error {
+		applied, err := m.verify(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[key(migration.Module, migration.Version)]; ok {
+				continue
+			}
+
+			if err := m.store.Apply(ctx, migration); err != nil {
+				return fmt.Errorf("[migration] cannot apply %s: %w", migration, err)
+			}
+			executed = append(executed, migration)
+		}
+		return nil
+	})
+	return executed, err
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("[migration] down steps must be greater than zero, got %d", steps)
+	}
+
+	var reverted []*Migration
+	err := m.locked(ctx, func() error {
+		if _, err := m.verify(ctx); err != nil {
+			return err
+		}
+
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.find(applied[i].Module, applied[i].Version)
+			if migration.Down == "" {
+				return fmt.Errorf("[migration] %s has no down file", migration)
+			}
+
+			if err := m.store.Revert(ctx, migration); err != nil {
+				return fmt.Errorf("[migration] cannot revert %s: %w", migration, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]Applied, len(applied))
+	for _, a := range applied {
+		byKey[key(a.Module, a.Version)] = a
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if a, ok := byKey[key(migration.Module, migration.Version)]; ok {
+			appliedAt := a.AppliedAt
+			status.AppliedAt = &appliedAt
+			status.Changed = a.Checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// verify refuses to run when an applied migration was edited or removed,
+// since the database would no longer match the files.
+func (m *Migrator) verify(ctx context.Context) (map[string]Applied, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]Applied, len(applied))
+	for _, a := range applied {
+		migration := m.find(a.Module, a.Version)
+		if migration == nil {
+			return nil, fmt.Errorf("[migration] %s/%s_%s is applied but its files are missing", a.Module, a.Version, a.Name)
+		}
+
+		if migration.Checksum != a.Checksum {
+			return nil, fmt.Errorf("[migration] %s changed after being applied, create a new migration instead", migration)
+		}
+		byKey[key(a.Module, a.Version)] = a
+	}
+	return byKey, nil
+}
+
+// applied returns the applied migrations of the migrator modules, oldest
+// first.
+func (m *Migrator) applied(ctx context.Context) ([]Applied, error) {
+	all, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Applied, 0, len(all))
+	for _, a := range all {
+		if m.modules[a.Module] {
+			applied = append(applied, a)
+		}
+	}
+	return applied, nil
+}
+
+func (m *Migrator) locked(ctx context.Context, fn func() error) error {
+	if err := m.store.Lock(ctx); err != nil {
+		return fmt.Errorf("[migration] cannot acquire lock: %w", err)
+	}
+
+	err := fn()
+	if unlockErr := m.store.Unlock(context.WithoutCancel(ctx)); unlockErr != nil && err == nil {
+		err = fmt.Errorf("[migration] cannot release lock: %w", unlockErr)
+	}
+	return err
+}
+
+func (m *Migrator) find(module string, version string) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Module == module && migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+func key(module string, version string) string {
+	return module + "/" + version
+}
@@ -0,0 +1,227 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/migration"
+)
+
+type memoryStore struct {
+	locked  bool
+	locks   int
+	applied []migration.Applied
+	failOn  string
+}
+
+func (s *memoryStore) Lock(ctx context.Context) error {
+	if s.locked {
+		return errors.New("already locked")
+	}
+	s.locked = true
+	s.locks++
+	return nil
+}
+
+func (s *memoryStore) Unlock(ctx context.Context) error {
+	s.locked = false
+	return nil
+}
+
+func (s *memoryStore) Applied(ctx context.Context) ([]migration.Applied, error) {
+	return append([]migration.Applied(nil), s.applied...), nil
+}
+
+func (s *memoryStore) Apply(ctx context.Context, m *migration.Migration) error {
+	if !s.locked {
+		return errors.New("not locked")
+	}
+	if m.Name == s.failOn {
+		return errors.New("syntax error")
+	}
+	s.applied = append(s.applied, migration.Applied{Module: m.Module, Version: m.Version, Name: m.Name, Checksum: m.Checksum, AppliedAt: time.Now()})
+	return nil
+}
+
+func (s *memoryStore) Revert(ctx context.Context, m *migration.Migration) error {
+	if !s.locked {
+		return errors.New("not locked")
+	}
+	for i, a := range s.applied {
+		if a.Module == m.Module && a.Version == m.Version {
+			s.applied = append(s.applied[:i], s.applied[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("not applied")
+}
+
+func userSource() migration.Source {
+	return migration.Source{Module: "user", FS: fstest.MapFS{
+		"20260101000000_create_schema.up.sql":   {Data: []byte("CREATE SCHEMA app;")},
+		"20260101000000_create_schema.down.sql": {Data: []byte("DROP SCHEMA app;")},
+		"20260102000000_create_users.up.sql":    {Data: []byte("CREATE TABLE app.users ();")},
+		"20260102000000_create_users.down.sql":  {Data: []byte("DROP TABLE app.users;")},
+		"20260103000000_create_groups.up.sql":   {Data: []byte("CREATE TABLE app.groups ();")},
+	}}
+}
+
+func names(migrations []*migration.Migration) string {
+	result := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		result = append(result, m.Name)
+	}
+	return strings.Join(result, ",")
+}
+
+func TestMigratorUp(t *testing.T) {
+	t.Run("Should apply pending migrations in order and only once", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{}
+		migrator, _ := migration.NewMigrator(store, userSource())
+
+		applied, err := migrator.Up(context.Background())
+		if err != nil || names(applied) != "create_schema,create_users,create_groups" {
+			t.Fatalf("Should apply every migration, got: %s, %v", names(applied), err)
+		}
+
+		applied, err = migrator.Up(context.Background())
+		if err != nil || len(applied) != 0 {
+			t.Errorf("Should have nothing pending, got: %s, %v", names(applied), err)
+		}
+
+		if store.locked || store.locks != 2 {
+			t.Errorf("Should lock and release on every run, got locked=%v locks=%d", store.locked, store.locks)
+		}
+	})
+
+	t.Run("Should stop at the failing migration and release the lock", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{failOn: "create_users"}
+		migrator, _ := migration.NewMigrator(store, userSource())
+
+		applied, err := migrator.Up(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "user/20260102000000_create_users") {
+			t.Errorf("Should name the failing migration, got: %v", err)
+		}
+
+		if names(applied) != "create_schema" || store.locked {
+			t.Errorf("Should keep previous migrations and unlock, got: %s, locked=%v", names(applied), store.locked)
+		}
+	})
+
+	t.Run("Should refuse to run when an applied migration changed", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{applied: []migration.Applied{{Module: "user", Version: "20260101000000", Name: "create_schema", Checksum: "outdated"}}}
+		migrator, _ := migration.NewMigrator(store, userSource())
+
+		applied, err := migrator.Up(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "changed after being applied") || len(applied) != 0 {
+			t.Errorf("Should return checksum error, got: %s, %v", names(applied), err)
+		}
+	})
+
+	t.Run("Should refuse to run when an applied migration has no files", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{applied: []migration.Applied{{Module: "user", Version: "20250101000000", Name: "dropped"}}}
+		migrator, _ := migration.NewMigrator(store, userSource())
+
+		_, err := migrator.Up(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "files are missing") {
+			t.Errorf("Should return missing files error, got: %v", err)
+		}
+	})
+
+	t.Run("Should ignore the migrations applied by other modules", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{applied: []migration.Applied{{Module: "billing", Version: "20260101000000", Name: "create_invoices"}}}
+		migrator, _ := migration.NewMigrator(store, userSource())
+
+		executed, err := migrator.Up(context.Background())
+		if err != nil || names(executed) != "create_schema,create_users,create_groups" {
+			t.Errorf("Should apply the module migrations, got: %s, %v", names(executed), err)
+		}
+	})
+}
+
+func TestMigratorDown(t *testing.T) {
+	t.Run("Should revert the last applied migrations newest first", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{}
+		source := userSource()
+		delete(source.FS.(fstest.MapFS), "20260103000000_create_groups.up.sql")
+		migrator, _ := migration.NewMigrator(store, source)
+		_, _ = migrator.Up(context.Background())
+
+		reverted, err := migrator.Down(context.Background(), 5)
+		if err != nil || names(reverted) != "create_users,create_schema" {
+			t.Errorf("Should revert every applied migration, got: %s, %v", names(reverted), err)
+		}
+
+		if len(store.applied) != 0 {
+			t.Errorf("Should remove applied records, got: %v", store.applied)
+		}
+	})
+
+	t.Run("Should only revert the migrations of its modules", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{}
+		source := userSource()
+		delete(source.FS.(fstest.MapFS), "20260103000000_create_groups.up.sql")
+		migrator, _ := migration.NewMigrator(store, source)
+		_, _ = migrator.Up(context.Background())
+		store.applied = append(store.applied, migration.Applied{Module: "billing", Version: "20260201000000", Name: "create_invoices"})
+
+		reverted, err := migrator.Down(context.Background(), 1)
+		if err != nil || names(reverted) != "create_users" {
+			t.Errorf("Should revert the last user migration, got: %s, %v", names(reverted), err)
+		}
+
+		if len(store.applied) != 2 || store.applied[1].Module != "billing" {
+			t.Errorf("Should keep the other module records, got: %v", store.applied)
+		}
+	})
+
+	t.Run("Should fail when the migration has no down file", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{}
+		migrator, _ := migration.NewMigrator(store, userSource())
+		_, _ = migrator.Up(context.Background())
+
+		reverted, err := migrator.Down(context.Background(), 1)
+		if err == nil || !strings.Contains(err.Error(), "has no down file") || len(reverted) != 0 {
+			t.Errorf("Should return missing down error, got: %s, %v", names(reverted), err)
+		}
+	})
+
+	t.Run("Should fail with less than one step", func(t *testing.T) {
+		t.Parallel()
+		migrator, _ := migration.NewMigrator(&memoryStore{}, userSource())
+		if _, err := migrator.Down(context.Background(), 0); err == nil {
+			t.Error("Should return an error")
+		}
+	})
+}
+
+func TestMigratorStatus(t *testing.T) {
+	t.Run("Should report applied, pending and changed migrations", func(t *testing.T) {
+		t.Parallel()
+		store := &memoryStore{applied: []migration.Applied{
+			{Module: "user", Version: "20260102000000", Name: "create_users", Checksum: "outdated", AppliedAt: time.Now()},
+		}}
+		migrator, _ := migration.NewMigrator(store, userSource())
+
+		statuses, err := migrator.Status(context.Background())
+		if err != nil || len(statuses) != 3 {
+			t.Fatalf("Should list every migration, got: %v, %v", statuses, err)
+		}
+
+		if statuses[0].AppliedAt != nil || statuses[1].AppliedAt == nil || !statuses[1].Changed {
+			t.Errorf("Should flag create_users as applied and changed, got: %+v", statuses)
+		}
+	})
+}
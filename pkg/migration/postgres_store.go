@@ -0,0 +1,137 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// lockId is the pg_advisory_lock key shared by every migrator.
+const lockId = 7243029183
+
+const createTable = `CREATE TABLE IF NOT EXISTS public.schema_migrations (
+	module     text        NOT NULL,
+	version    text        NOT NULL,
+	name       text        NOT NULL,
+	checksum   text        NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (module, version)
+)`
+
+// PostgresStore keeps the applied migrations in public.schema_migrations.
+// Advisory locks belong to a session, so the store pins a single connection
+// between Lock and Unlock.
+type PostgresStore struct {
+	db   *sql.DB
+	conn *sql.Conn
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Lock(ctx context.Context) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockId); err != nil {
+		conn.Close()
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockId)
+		conn.Close()
+		return err
+	}
+
+	s.conn = conn
+	return nil
+}
+
+func (s *PostgresStore) Unlock(ctx context.Context) error {
+	if s.conn == nil {
+		return nil
+	}
+
+	_, err := s.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockId)
+	err = errors.Join(err, s.conn.Close())
+	s.conn = nil
+	return err
+}
+
+func (s *PostgresStore) Applied(ctx context.Context) ([]Applied, error) {
+	query := "SELECT module, version, name, checksum, applied_at FROM public.schema_migrations ORDER BY applied_at, version"
+	var rows *sql.Rows
+	var err error
+	if s.conn != nil {
+		rows, err = s.conn.QueryContext(ctx, query)
+	} else {
+		rows, err = s.db.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		if s.conn == nil && isUndefinedTable(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make([]Applied, 0)
+	for rows.Next() {
+		var a Applied
+		if err := rows.Scan(&a.Module, &a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied = append(applied, a)
+	}
+	return applied, rows.Err()
+}
+
+func (s *PostgresStore) Apply(ctx context.Context, migration *Migration) error {
+	return s.transaction(ctx, migration.Up,
+		"INSERT INTO public.schema_migrations (module, version, name, checksum) VALUES ($1, $2, $3, $4)",
+		migration.Module, migration.Version, migration.Name, migration.Checksum,
+	)
+}
+
+func (s *PostgresStore) Revert(ctx context.Context, migration *Migration) error {
+	return s.transaction(ctx, migration.Down,
+		"DELETE FROM public.schema_migrations WHERE module = $1 AND version = $2",
+		migration.Module, migration.Version,
+	)
+}
+
+// transaction runs the script and its bookkeeping statement together, so a
+// failing migration leaves neither the schema change nor the record behind.
+func (s *PostgresStore) transaction(ctx context.Context, script string, record string, args ...any) error {
+	if s.conn == nil {
+		return errors.New("migration lock not acquired")
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isUndefinedTable lets status run against a database nobody migrated yet.
+func isUndefinedTable(err error) bool {
+	var coded interface{ SQLState() string }
+	return errors.As(err, &coded) && coded.SQLState() == "42P01"
+}
@@ -6,4 +6,11 @@ import (
 
 type Module interface {
 	Register(ctx context.Context) error
-}
\ No newline at end of file
+}
+
+// ConsumerModule is implemented by modules that consume messages. Consumers
+// start once gomes is running and stop before it shuts down.
+type ConsumerModule interface {
+	StartConsumers(ctx context.Context) error
+	StopConsumers()
+}
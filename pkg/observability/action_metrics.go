@@ -0,0 +1,68 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jeffersonbrasilino/gomes/message/handler"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+const instrumentationName = "github.com/jeffersonbrasilino/hex-api-go/pkg/observability"
+
+// actionNameKey is the Name() of the command, query or event handled.
+const actionNameKey = attribute.Key("gomes.action.name")
+
+var actionBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type measuredHandler[T handler.Action, U any] struct {
+	name     attribute.KeyValue
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	handler  handler.ActionHandler[T, U]
+}
+
+// Measure wraps an action handler recording its latency and its errors by
+// action name. Instruments come from the global meter provider, so handlers
+// wrapped before Start report once it installs the provider.
+func Measure[T handler.Action, U any](next handler.ActionHandler[T, U]) handler.ActionHandler[T, U] {
+	meter := otel.Meter(instrumentationName)
+
+	// the meter only fails on invalid instrument names, which are constants here
+	duration, _ := meter.Float64Histogram(
+		"gomes.action.duration",
+		metric.WithDescription("Duration of command, query and event handlers."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(actionBuckets...),
+	)
+	errors, _ := meter.Int64Counter(
+		"gomes.action.errors",
+		metric.WithDescription("Number of command, query and event handlers that failed."),
+		metric.WithUnit("{error}"),
+	)
+
+	return &measuredHandler[T, U]{
+		name:     actionNameKey.String((*new(T)).Name()),
+		duration: duration,
+		errors:   errors,
+		handler:  next,
+	}
+}
+
+func (h *measuredHandler[T, U]) Handle(ctx context.Context, action T) (U, error) {
+	start := time.Now()
+	output, err := h.handler.Handle(ctx, action)
+
+	attributes := metric.WithAttributes(h.name)
+	if err != nil {
+		attributes = metric.WithAttributes(h.name, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+		h.errors.Add(ctx, 1, attributes)
+	}
+
+	h.duration.Record(ctx, time.Since(start).Seconds(), attributes)
+	return output, err
+}
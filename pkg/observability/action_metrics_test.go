@@ -0,0 +1,90 @@
+package observability_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type fakeCommand struct {
+	fail bool
+}
+
+func (c *fakeCommand) Name() string {
+	return "fakeCommand"
+}
+
+type fakeHandler struct{}
+
+func (h *fakeHandler) Handle(ctx context.Context, command *fakeCommand) (any, error) {
+	if command.fail {
+		return nil, ddgo.NewNotFoundError("user not found")
+	}
+	return "ok", nil
+}
+
+func collect(t *testing.T, reader *metric.ManualReader, name string) metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Should collect the metrics, got: %v", err)
+	}
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+
+	t.Fatalf("Should record %s", name)
+	return nil
+}
+
+func TestMeasure(t *testing.T) {
+	reader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(reader)))
+
+	measured := observability.Measure(&fakeHandler{})
+	measured.Handle(context.Background(), &fakeCommand{})
+	_, err := measured.Handle(context.Background(), &fakeCommand{fail: true})
+
+	t.Run("Should return the handler error", func(t *testing.T) {
+		var notFound *ddgo.NotFoundError
+		if !errors.As(err, &notFound) {
+			t.Errorf("Should return the not found error, got: %v", err)
+		}
+	})
+
+	t.Run("Should record the duration by action name", func(t *testing.T) {
+		histogram := collect(t, reader, "gomes.action.duration").(metricdata.Histogram[float64])
+		var total uint64
+		for _, point := range histogram.DataPoints {
+			if name, _ := point.Attributes.Value("gomes.action.name"); name.AsString() != "fakeCommand" {
+				t.Errorf("Should label the action name, got: %s", name.AsString())
+			}
+			total += point.Count
+		}
+		if total != 2 {
+			t.Errorf("Should record 2 calls, got: %d", total)
+		}
+	})
+
+	t.Run("Should count the errors by type", func(t *testing.T) {
+		sum := collect(t, reader, "gomes.action.errors").(metricdata.Sum[int64])
+		if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1 {
+			t.Fatalf("Should count 1 error, got: %+v", sum.DataPoints)
+		}
+		errorType, _ := sum.DataPoints[0].Attributes.Value(attribute.Key("error.type"))
+		if errorType.AsString() != "*ddgo.NotFoundError" {
+			t.Errorf("Should label the error type, got: %s", errorType.AsString())
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package observability
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Config drives the telemetry bootstrap. The OTLP exporter reads its own
+// OTEL_EXPORTER_OTLP_* variables.
+type Config struct {
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+	// SampleRatio is the share of new traces recorded, from 0 to 1. Spans
+	// with a sampled parent are always recorded.
+	SampleRatio float64
+	// TracingEnabled false keeps the no-op tracer provider.
+	TracingEnabled bool
+	// MetricsEnabled false keeps the no-op meter provider and serves no
+	// /metrics route.
+	MetricsEnabled bool
+	// ProfilingAddress is the pyroscope server, empty disables profiling.
+	ProfilingAddress string
+}
+
+// ConfigFromEnv reads OTEL_SERVICE_NAME (APP_NAME when empty), APP_VERSION,
+// APP_ENV, OTEL_TRACES_SAMPLER_ARG, OTEL_SDK_DISABLED and
+// PYROSCOPE_SERVER_ADDRESS.
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{
+		ServiceName:      os.Getenv("OTEL_SERVICE_NAME"),
+		ServiceVersion:   os.Getenv("APP_VERSION"),
+		Environment:      os.Getenv("APP_ENV"),
+		SampleRatio:      1,
+		TracingEnabled:   true,
+		MetricsEnabled:   true,
+		ProfilingAddress: os.Getenv("PYROSCOPE_SERVER_ADDRESS"),
+	}
+
+	if config.ServiceName == "" {
+		config.ServiceName = os.Getenv("APP_NAME")
+	}
+
+	if config.ServiceVersion == "" {
+		config.ServiceVersion = "dev"
+	}
+
+	if value := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be a ratio between 0 and 1, got %q", value)
+		}
+		config.SampleRatio = ratio
+	}
+
+	if value := os.Getenv("OTEL_SDK_DISABLED"); value != "" {
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("OTEL_SDK_DISABLED must be a boolean, got %q", value)
+		}
+		config.TracingEnabled = !disabled
+		config.MetricsEnabled = !disabled
+	}
+
+	return config, nil
+}
@@ -0,0 +1,61 @@
+package observability_test
+
+import (
+	"testing"
+
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/observability"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Run("Should fall back to the app name and record every trace", func(t *testing.T) {
+		t.Setenv("OTEL_SERVICE_NAME", "")
+		t.Setenv("APP_NAME", "hex-api-go")
+		t.Setenv("APP_VERSION", "")
+		t.Setenv("APP_ENV", "homo")
+		t.Setenv("OTEL_TRACES_SAMPLER_ARG", "")
+		t.Setenv("OTEL_SDK_DISABLED", "")
+
+		config, err := observability.ConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Should read the config, got: %v", err)
+		}
+
+		expected := observability.Config{ServiceName: "hex-api-go", ServiceVersion: "dev", Environment: "homo", SampleRatio: 1, TracingEnabled: true, MetricsEnabled: true}
+		if *config != expected {
+			t.Errorf("Should return %+v, got: %+v", expected, *config)
+		}
+	})
+
+	t.Run("Should read the sampling ratio and the kill switch", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+		t.Setenv("OTEL_SDK_DISABLED", "true")
+
+		config, err := observability.ConfigFromEnv()
+		if err != nil {
+			t.Fatalf("Should read the config, got: %v", err)
+		}
+
+		if config.SampleRatio != 0.25 || config.TracingEnabled || config.MetricsEnabled {
+			t.Errorf("Should sample 0.25 with tracing and metrics disabled, got: %+v", config)
+		}
+	})
+
+	invalid := []struct {
+		description string
+		key         string
+		value       string
+	}{
+		{"Should return error for a ratio above 1", "OTEL_TRACES_SAMPLER_ARG", "1.5"},
+		{"Should return error for a ratio that is not a number", "OTEL_TRACES_SAMPLER_ARG", "half"},
+		{"Should return error for a kill switch that is not a boolean", "OTEL_SDK_DISABLED", "maybe"},
+	}
+
+	for _, c := range invalid {
+		t.Run(c.description, func(t *testing.T) {
+			t.Setenv(c.key, c.value)
+			if _, err := observability.ConfigFromEnv(); err == nil {
+				t.Error(c.description)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// newMeterProvider exports the metrics twice: pushed to the collector through
+// OTLP on OTEL_METRIC_EXPORT_INTERVAL, and pulled by Prometheus through the
+// returned handler.
+func newMeterProvider(ctx context.Context, res *resource.Resource) (*metric.MeterProvider, http.Handler, error) {
+	otlpExporter, err := otlpmetricgrpc.New(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP grpc metric exporter: %w", err)
+	}
+
+	registry := prometheus.NewRegistry()
+	err = registry.Register(collectors.NewGoCollector())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to register go collector: %w", err)
+	}
+
+	err = registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to register process collector: %w", err)
+	}
+
+	prometheusExporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create prometheus metric exporter: %w", err)
+	}
+
+	provider := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(metric.NewPeriodicReader(otlpExporter)),
+		metric.WithReader(prometheusExporter),
+	)
+
+	return provider, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
+}
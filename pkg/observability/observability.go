@@ -0,0 +1,190 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grafana/pyroscope-go"
+	gomes "github.com/jeffersonbrasilino/gomes"
+	pkghttp "github.com/jeffersonbrasilino/hex-api-go/pkg/http"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"gorm.io/gorm"
+)
+
+// Telemetry holds what Start set up, Shutdown flushes and releases it.
+type Telemetry struct {
+	config         *Config
+	tracerProvider *trace.TracerProvider
+	meterProvider  *metric.MeterProvider
+	metricsHandler http.Handler
+	profiler       *pyroscope.Profiler
+}
+
+// Start installs the W3C trace-context and baggage propagators and, when
+// enabled, the global tracer and meter providers and the profiler. It enables
+// the gomes tracing too, so it must run before gomes.Start.
+func Start(ctx context.Context, config *Config) (*Telemetry, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	telemetry := &Telemetry{config: config}
+	if config.TracingEnabled || config.MetricsEnabled {
+		res, err := newResource(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+
+		if config.TracingEnabled {
+			provider, err := newTracerProvider(ctx, config, res)
+			if err != nil {
+				return nil, err
+			}
+
+			otel.SetTracerProvider(provider)
+			gomes.EnableOtelTrace()
+			telemetry.tracerProvider = provider
+		}
+
+		if config.MetricsEnabled {
+			provider, handler, err := newMeterProvider(ctx, res)
+			if err != nil {
+				telemetry.Shutdown(ctx)
+				return nil, err
+			}
+
+			otel.SetMeterProvider(provider)
+			telemetry.meterProvider = provider
+			telemetry.metricsHandler = handler
+		}
+	}
+
+	if config.ProfilingAddress != "" {
+		profiler, err := startProfiler(config)
+		if err != nil {
+			telemetry.Shutdown(ctx)
+			return nil, err
+		}
+		telemetry.profiler = profiler
+	}
+
+	return telemetry, nil
+}
+
+// Instrument adds the tracing and metrics middlewares and the Prometheus
+// /metrics route to the engine and the metrics plugin to the database. Call it
+// before the modules register their routes, gin only applies middlewares to
+// the routes added after them.
+func (t *Telemetry) Instrument(engine *gin.Engine, db *gorm.DB) error {
+	engine.Use(pkghttp.Tracing())
+	if t.meterProvider == nil {
+		return nil
+	}
+
+	metrics, err := pkghttp.Metrics()
+	if err != nil {
+		return fmt.Errorf("failed to create http metrics: %w", err)
+	}
+	engine.Use(metrics)
+	engine.GET("/metrics", gin.WrapH(t.metricsHandler))
+
+	if err := db.Use(postgres.NewMetricsPlugin(t.config.ServiceName)); err != nil {
+		return fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	return nil
+}
+
+func newTracerProvider(ctx context.Context, config *Config, res *resource.Resource) (*trace.TracerProvider, error) {
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP grpc trace exporter: %w", err)
+	}
+
+	return trace.NewTracerProvider(
+		trace.WithBatcher(exporter),
+		trace.WithResource(res),
+		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(config.SampleRatio))),
+	), nil
+}
+
+// newResource describes the service, OTEL_RESOURCE_ATTRIBUTES may add to it.
+func newResource(ctx context.Context, config *Config) (*resource.Resource, error) {
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithFromEnv(),
+		resource.WithAttributes(
+			semconv.ServiceName(config.ServiceName),
+			semconv.ServiceVersion(config.ServiceVersion),
+			semconv.DeploymentEnvironmentName(config.Environment),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build telemetry resource: %w", err)
+	}
+
+	return res, nil
+}
+
+func startProfiler(config *Config) (*pyroscope.Profiler, error) {
+	profiler, err := pyroscope.Start(pyroscope.Config{
+		ApplicationName: config.ServiceName,
+		ServerAddress:   config.ProfilingAddress,
+		Tags: map[string]string{
+			"version":     config.ServiceVersion,
+			"environment": config.Environment,
+		},
+		ProfileTypes: []pyroscope.ProfileType{
+			pyroscope.ProfileCPU,
+			pyroscope.ProfileAllocObjects,
+			pyroscope.ProfileAllocSpace,
+			pyroscope.ProfileInuseObjects,
+			pyroscope.ProfileInuseSpace,
+			pyroscope.ProfileGoroutines,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start profiler: %w", err)
+	}
+
+	return profiler, nil
+}
+
+// Shutdown exports the spans and metrics not sent yet and stops the
+// profiler. Call it before gomes.Shutdown with a context that is not
+// cancelled yet, it bounds the flush.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	var errs []error
+	if t.tracerProvider != nil {
+		if err := t.tracerProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down tracer provider: %w", err))
+		}
+	}
+
+	if t.meterProvider != nil {
+		if err := t.meterProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down meter provider: %w", err))
+		}
+	}
+
+	if t.profiler != nil {
+		if err := t.profiler.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop profiler: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
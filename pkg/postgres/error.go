@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jeffersonbrasilino/ddgo"
+)
+
+// SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	notNullViolation     = "23502"
+	foreignKeyViolation  = "23503"
+	uniqueViolation      = "23505"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// detailKey matches the column list in details such as
+// `Key (username)=(johndoe) already exists.`
+var detailKey = regexp.MustCompile(`Key \(([^)]+)\)=`)
+
+type fieldValidation struct {
+	IsValid          bool
+	FailedValidators []string
+}
+
+// TranslateError converts a database error into the ddgo error the HTTP layer
+// knows how to answer:
+//   - unique violation: AlreadyExistsError (409) naming the duplicated field;
+//   - foreign key and not null violations: InvalidDataError (422) naming the field;
+//   - serialization failure and deadlock: DependencyError (502), safe to retry;
+//   - anything else: InternalError (500).
+//
+// Field errors use the same JSON shape as domain validation errors. A nil err
+// returns nil, so it can wrap tx.Commit().Error directly.
+func TranslateError(operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ddgo.NewInternalError(fmt.Sprintf("Error to %s: %s", operation, err.Error()))
+	}
+
+	switch pgErr.Code {
+	case uniqueViolation:
+		return ddgo.NewAlreadyExistsError(fieldError(field(pgErr), "unique"))
+	case foreignKeyViolation:
+		return ddgo.NewInvalidDataError(fieldError(field(pgErr), "exists"))
+	case notNullViolation:
+		return ddgo.NewInvalidDataError(fieldError(field(pgErr), "required"))
+	case serializationFailure, deadlockDetected:
+		return ddgo.NewDependencyError(fmt.Sprintf("Error to %s: concurrent update conflict, try again", operation))
+	default:
+		return ddgo.NewInternalError(fmt.Sprintf("Error to %s: %s", operation, err.Error()))
+	}
+}
+
+// field names the offending column in camelCase, as clients send it. It
+// falls back to the constraint name when Postgres does not report a column.
+func field(pgErr *pgconn.PgError) string {
+	column := pgErr.ColumnName
+	if matches := detailKey.FindStringSubmatch(pgErr.Detail); matches != nil {
+		column = matches[1]
+	}
+
+	if column == "" {
+		return pgErr.ConstraintName
+	}
+
+	parts := strings.Split(strings.ReplaceAll(column, ", ", "_"), "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func fieldError(field string, failedValidators ...string) string {
+	result, err := json.Marshal(map[string]fieldValidation{
+		field: {IsValid: false, FailedValidators: failedValidators},
+	})
+	if err != nil {
+		return field
+	}
+	return string(result)
+}
@@ -0,0 +1,78 @@
+package postgres_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jeffersonbrasilino/ddgo"
+	"github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+)
+
+func TestTranslateError(t *testing.T) {
+	cases := []struct {
+		description string
+		err         error
+		expected    string
+		check       func(err error) bool
+	}{
+		{
+			description: "Should return already exists naming the duplicated field",
+			err:         &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_username", Detail: "Key (username)=(johndoe) already exists."},
+			expected:    `{"username":{"IsValid":false,"FailedValidators":["unique"]}}`,
+			check:       func(err error) bool { _, ok := err.(*ddgo.AlreadyExistsError); return ok },
+		},
+		{
+			description: "Should return invalid data naming the missing reference",
+			err:         fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "23503", ConstraintName: "fk_users_person", Detail: `Key (person_id)=(42) is not present in table "persons".`}),
+			expected:    `{"personId":{"IsValid":false,"FailedValidators":["exists"]}}`,
+			check:       func(err error) bool { _, ok := err.(*ddgo.InvalidDataError); return ok },
+		},
+		{
+			description: "Should return invalid data naming the null column",
+			err:         &pgconn.PgError{Code: "23502", ColumnName: "birth_date"},
+			expected:    `{"birthDate":{"IsValid":false,"FailedValidators":["required"]}}`,
+			check:       func(err error) bool { _, ok := err.(*ddgo.InvalidDataError); return ok },
+		},
+		{
+			description: "Should fall back to the constraint name",
+			err:         &pgconn.PgError{Code: "23505", ConstraintName: "idx_persons_document"},
+			expected:    `{"idx_persons_document":{"IsValid":false,"FailedValidators":["unique"]}}`,
+			check:       func(err error) bool { _, ok := err.(*ddgo.AlreadyExistsError); return ok },
+		},
+		{
+			description: "Should return dependency error on serialization failure",
+			err:         &pgconn.PgError{Code: "40001"},
+			expected:    "Error to create user: concurrent update conflict, try again",
+			check:       func(err error) bool { _, ok := err.(*ddgo.DependencyError); return ok },
+		},
+		{
+			description: "Should return internal error for other failures",
+			err:         errors.New("connection refused"),
+			expected:    "Error to create user: connection refused",
+			check:       func(err error) bool { _, ok := err.(*ddgo.InternalError); return ok },
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			t.Parallel()
+			err := postgres.TranslateError("create user", c.err)
+			if !c.check(err) {
+				t.Errorf("Should return the mapped error type, got: %T", err)
+			}
+
+			if err.Error() != c.expected {
+				t.Errorf("Should return %s, got: %s", c.expected, err.Error())
+			}
+		})
+	}
+
+	t.Run("Should return nil without error", func(t *testing.T) {
+		t.Parallel()
+		if err := postgres.TranslateError("create user", nil); err != nil {
+			t.Errorf("Should return nil, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"gorm.io/gorm"
+)
+
+const (
+	instrumentationName = "github.com/jeffersonbrasilino/hex-api-go/pkg/postgres"
+	startedAtKey        = "metrics:started_at"
+)
+
+// queryBuckets are the boundaries, in seconds, recommended by the database
+// semantic conventions.
+var queryBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10}
+
+// MetricsPlugin is a gorm plugin recording the duration of every statement
+// and observing the connection pool of the database it is registered on.
+type MetricsPlugin struct {
+	poolName string
+	duration metric.Float64Histogram
+}
+
+// NewMetricsPlugin creates the plugin, poolName tells apart the pools of
+// different connections.
+func NewMetricsPlugin(poolName string) *MetricsPlugin {
+	return &MetricsPlugin{poolName: poolName}
+}
+
+func (p *MetricsPlugin) Name() string {
+	return "hex-api-go:metrics"
+}
+
+func (p *MetricsPlugin) Initialize(db *gorm.DB) error {
+	meter := otel.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram(
+		"db.client.operation.duration",
+		metric.WithDescription("Duration of database client operations."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(queryBuckets...),
+	)
+	if err != nil {
+		return err
+	}
+	p.duration = duration
+
+	if err := p.registerCallbacks(db); err != nil {
+		return err
+	}
+
+	return p.observePool(db, meter)
+}
+
+func (p *MetricsPlugin) registerCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("*").Register("metrics:before_create", p.before),
+		callback.Create().After("*").Register("metrics:after_create", p.after),
+		callback.Query().Before("*").Register("metrics:before_query", p.before),
+		callback.Query().After("*").Register("metrics:after_query", p.after),
+		callback.Update().Before("*").Register("metrics:before_update", p.before),
+		callback.Update().After("*").Register("metrics:after_update", p.after),
+		callback.Delete().Before("*").Register("metrics:before_delete", p.before),
+		callback.Delete().After("*").Register("metrics:after_delete", p.after),
+		callback.Row().Before("*").Register("metrics:before_row", p.before),
+		callback.Row().After("*").Register("metrics:after_row", p.after),
+		callback.Raw().Before("*").Register("metrics:before_raw", p.before),
+		callback.Raw().After("*").Register("metrics:after_raw", p.after),
+	)
+}
+
+func (p *MetricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startedAtKey, time.Now())
+}
+
+func (p *MetricsPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(startedAtKey)
+	if !ok {
+		return
+	}
+
+	startedAt, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operationName(db.Statement.SQL.String())),
+	}
+	if db.Statement.Table != "" {
+		attributes = append(attributes, semconv.DBCollectionName(db.Statement.Table))
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		attributes = append(attributes, semconv.ErrorTypeKey.String(errorType(db.Error)))
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p.duration.Record(ctx, time.Since(startedAt).Seconds(), metric.WithAttributes(attributes...))
+}
+
+// observePool reports the database/sql pool stats on every collection.
+func (p *MetricsPlugin) observePool(db *gorm.DB, meter metric.Meter) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	connections, err := meter.Int64ObservableUpDownCounter(
+		"db.client.connection.count",
+		metric.WithDescription("Number of connections by state."),
+		metric.WithUnit("{connection}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	maxConnections, err := meter.Int64ObservableUpDownCounter(
+		"db.client.connection.max",
+		metric.WithDescription("Maximum number of open connections allowed."),
+		metric.WithUnit("{connection}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	waits, err := meter.Int64ObservableCounter(
+		"db.client.connection.wait_count",
+		metric.WithDescription("Number of times a caller waited for a free connection."),
+		metric.WithUnit("{wait}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	waitDuration, err := meter.Float64ObservableCounter(
+		"db.client.connection.wait_duration",
+		metric.WithDescription("Total time callers waited for a free connection."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	pool := semconv.DBClientConnectionPoolName(p.poolName)
+	_, err = meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		stats := sqlDB.Stats()
+		observer.ObserveInt64(connections, int64(stats.Idle), metric.WithAttributes(pool, semconv.DBClientConnectionStateIdle))
+		observer.ObserveInt64(connections, int64(stats.InUse), metric.WithAttributes(pool, semconv.DBClientConnectionStateUsed))
+		observer.ObserveInt64(maxConnections, int64(stats.MaxOpenConnections), metric.WithAttributes(pool))
+		observer.ObserveInt64(waits, stats.WaitCount, metric.WithAttributes(pool))
+		observer.ObserveFloat64(waitDuration, stats.WaitDuration.Seconds(), metric.WithAttributes(pool))
+		return nil
+	}, connections, maxConnections, waits, waitDuration)
+
+	return err
+}
+
+// operationName is the SQL keyword the statement starts with, raw SQL has
+// no other place to read it from.
+func operationName(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "UNKNOWN"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+// errorType names the error the repositories would surface, e.g.
+// *ddgo.AlreadyExistsError for a unique violation.
+func errorType(err error) string {
+	return fmt.Sprintf("%T", TranslateError("", err))
+}
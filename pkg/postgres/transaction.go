@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+// Transaction runs fn in a database transaction carried by the context given
+// to fn. Repositories getting their connection through Conn and Begin join
+// it, so everything fn writes commits or rolls back together. Called inside
+// another Transaction it joins the outer one.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context, tx *gorm.DB) error) error {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return fn(ctx, tx)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx), tx)
+	})
+}
+
+// Conn returns the transaction carried by ctx, or db outside of one.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db
+}
+
+// Begin starts a transaction on db. Inside Transaction it returns the carried
+// one with Commit and Rollback doing nothing: the error the repository
+// returns rolls everything back where the transaction was opened.
+func Begin(ctx context.Context, db *gorm.DB) *gorm.DB {
+	tx, ok := ctx.Value(transactionKey{}).(*gorm.DB)
+	if !ok {
+		return db.Begin()
+	}
+
+	joined := tx.Session(&gorm.Session{Context: ctx})
+	joined.Statement.ConnPool = joinedTransaction{tx.Statement.ConnPool}
+	return joined
+}
+
+type joinedTransaction struct {
+	gorm.ConnPool
+}
+
+func (joinedTransaction) Commit() error {
+	return nil
+}
+
+func (joinedTransaction) Rollback() error {
+	return nil
+}